@@ -0,0 +1,238 @@
+package mailer
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/hook"
+)
+
+var _ Mailer = (*Chain)(nil)
+
+// circuitState defines the state of a single provider's circuit breaker.
+type circuitState string
+
+const (
+	circuitClosed   circuitState = "closed"
+	circuitOpen     circuitState = "open"
+	circuitHalfOpen circuitState = "half-open"
+)
+
+// ProviderStatus exposes the health of a single [Chain] provider for
+// introspection (eg. an admin "mail providers" status page).
+type ProviderStatus struct {
+	Name                string
+	State               string
+	ConsecutiveFailures int
+	OpenedAt            time.Time
+}
+
+// chainProvider wraps a single [Mailer] with its circuit-breaker state.
+type chainProvider struct {
+	name   string
+	mailer Mailer
+
+	mu                  sync.Mutex
+	state               circuitState
+	consecutiveFailures int
+	openedAt            time.Time
+}
+
+// ChainConfig configures the circuit-breaker behavior of a [Chain].
+type ChainConfig struct {
+	// FailureThreshold is the number of consecutive failures after
+	// which a provider's circuit is opened. Zero falls back to 3.
+	FailureThreshold int
+
+	// CoolDown is how long a provider's circuit stays open before
+	// moving to half-open and being tried again. Zero falls back to 30s.
+	CoolDown time.Duration
+}
+
+// ChainSendEvent is triggered by [Chain.OnSend] and aggregates the
+// outcome of the attempted providers.
+type ChainSendEvent struct {
+	Message *Message
+
+	// Provider is the name of the provider that ultimately handled
+	// (or failed to handle) the message.
+	Provider string
+
+	// Attempts holds the ordered list of provider names that were tried.
+	Attempts []string
+}
+
+// Chain implements [Mailer] by trying an ordered list of providers
+// until one succeeds, skipping providers whose circuit is currently
+// open due to repeated recent failures.
+//
+// Auth/config errors (eg. a [ResendAPIError] with a 401/403 status)
+// are not retried against other providers, since they indicate a
+// misconfiguration rather than a transient outage.
+type Chain struct {
+	onSend *hook.Hook[*ChainSendEvent]
+
+	config    ChainConfig
+	providers []*chainProvider
+}
+
+// NewChain creates a new [Chain] trying providers in the given order.
+//
+// Each key in providers is used purely for status introspection via
+// [Chain.Providers] and should be unique (eg. "resend", "smtp", "sendmail").
+// Keys in order without a matching entry in providers are skipped.
+func NewChain(config ChainConfig, providers map[string]Mailer, order []string) *Chain {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = 3
+	}
+
+	if config.CoolDown <= 0 {
+		config.CoolDown = 30 * time.Second
+	}
+
+	c := &Chain{config: config}
+
+	for _, name := range order {
+		mailer, ok := providers[name]
+		if !ok {
+			continue
+		}
+
+		c.providers = append(c.providers, &chainProvider{
+			name:   name,
+			mailer: mailer,
+			state:  circuitClosed,
+		})
+	}
+
+	return c
+}
+
+// OnSend returns the hook triggered after the chain has attempted to
+// deliver a message, whether it ultimately succeeded or not.
+func (c *Chain) OnSend() *hook.Hook[*ChainSendEvent] {
+	if c.onSend == nil {
+		c.onSend = &hook.Hook[*ChainSendEvent]{}
+	}
+	return c.onSend
+}
+
+// Providers returns the current health status of each configured provider.
+func (c *Chain) Providers() []ProviderStatus {
+	result := make([]ProviderStatus, 0, len(c.providers))
+
+	for _, p := range c.providers {
+		p.mu.Lock()
+		result = append(result, ProviderStatus{
+			Name:                p.name,
+			State:               string(p.state),
+			ConsecutiveFailures: p.consecutiveFailures,
+			OpenedAt:            p.openedAt,
+		})
+		p.mu.Unlock()
+	}
+
+	return result
+}
+
+// Send implements [Mailer] by trying each provider in order until one
+// succeeds or all have been exhausted.
+func (c *Chain) Send(m *Message) error {
+	if c.onSend != nil {
+		return c.onSend.Trigger(&ChainSendEvent{Message: m}, func(e *ChainSendEvent) error {
+			return c.send(e)
+		})
+	}
+
+	return c.send(&ChainSendEvent{Message: m})
+}
+
+func (c *Chain) send(e *ChainSendEvent) error {
+	if len(c.providers) == 0 {
+		return errors.New("no mail providers configured")
+	}
+
+	var lastErr error
+
+	for _, p := range c.providers {
+		if !p.available(c.config.CoolDown) {
+			continue
+		}
+
+		e.Attempts = append(e.Attempts, p.name)
+		e.Provider = p.name
+
+		err := p.mailer.Send(e.Message)
+		if err == nil {
+			p.recordSuccess()
+			return nil
+		}
+
+		lastErr = err
+
+		if isConfigError(err) {
+			// Don't burn through the rest of the chain for what is
+			// almost certainly a misconfiguration, not an outage.
+			return fmt.Errorf("mail provider %q rejected the request (not retrying other providers): %w", p.name, err)
+		}
+
+		p.recordFailure(c.config.FailureThreshold)
+	}
+
+	if lastErr == nil {
+		return errors.New("all mail providers are currently unavailable")
+	}
+
+	return fmt.Errorf("all mail providers failed, last error: %w", lastErr)
+}
+
+// isConfigError reports whether err indicates an auth/config problem
+// (401/403) as opposed to a transient network or server error.
+func isConfigError(err error) bool {
+	var apiErr *ResendAPIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == 401 || apiErr.StatusCode == 403
+	}
+
+	return false
+}
+
+// available reports whether the provider's circuit allows a new attempt,
+// transitioning an open circuit to half-open once the cool-down elapses.
+func (p *chainProvider) available(coolDown time.Duration) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch p.state {
+	case circuitOpen:
+		if time.Since(p.openedAt) >= coolDown {
+			p.state = circuitHalfOpen
+			return true
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+func (p *chainProvider) recordSuccess() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.state = circuitClosed
+	p.consecutiveFailures = 0
+}
+
+func (p *chainProvider) recordFailure(threshold int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.consecutiveFailures++
+
+	if p.state == circuitHalfOpen || p.consecutiveFailures >= threshold {
+		p.state = circuitOpen
+		p.openedAt = time.Now()
+	}
+}
@@ -0,0 +1,125 @@
+package mailer
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakeMailer struct {
+	err   error
+	calls int
+}
+
+func (m *fakeMailer) Send(msg *Message) error {
+	m.calls++
+	return m.err
+}
+
+func TestChainFailsOverToNextProvider(t *testing.T) {
+	primary := &fakeMailer{err: errors.New("network timeout")}
+	fallback := &fakeMailer{}
+
+	chain := NewChain(ChainConfig{}, map[string]Mailer{
+		"resend": primary,
+		"smtp":   fallback,
+	}, []string{"resend", "smtp"})
+
+	if err := chain.Send(newTestMessage()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if primary.calls != 1 || fallback.calls != 1 {
+		t.Fatalf("Expected both providers to be tried once, got primary=%d fallback=%d", primary.calls, fallback.calls)
+	}
+}
+
+func TestChainDoesNotFailoverOnConfigError(t *testing.T) {
+	primary := &fakeMailer{err: &ResendAPIError{StatusCode: 401, Message: "invalid api key"}}
+	fallback := &fakeMailer{}
+
+	chain := NewChain(ChainConfig{}, map[string]Mailer{
+		"resend": primary,
+		"smtp":   fallback,
+	}, []string{"resend", "smtp"})
+
+	if err := chain.Send(newTestMessage()); err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	if fallback.calls != 0 {
+		t.Fatalf("Expected the fallback provider to be skipped, got %d calls", fallback.calls)
+	}
+}
+
+func TestChainOpensCircuitAfterThreshold(t *testing.T) {
+	primary := &fakeMailer{err: errors.New("network timeout")}
+	fallback := &fakeMailer{}
+
+	chain := NewChain(ChainConfig{FailureThreshold: 2, CoolDown: 50 * time.Millisecond}, map[string]Mailer{
+		"resend": primary,
+		"smtp":   fallback,
+	}, []string{"resend", "smtp"})
+
+	for i := 0; i < 2; i++ {
+		if err := chain.Send(newTestMessage()); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	if primary.calls != 2 {
+		t.Fatalf("Expected the primary provider to be tried twice before opening, got %d", primary.calls)
+	}
+
+	// Circuit should now be open, so a 3rd send should skip straight to fallback.
+	if err := chain.Send(newTestMessage()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if primary.calls != 2 {
+		t.Fatalf("Expected the primary provider to be skipped while its circuit is open, got %d calls", primary.calls)
+	}
+
+	statuses := chain.Providers()
+	if statuses[0].State != string(circuitOpen) {
+		t.Fatalf("Expected primary provider state %q, got %q", circuitOpen, statuses[0].State)
+	}
+}
+
+func TestChainHalfOpenAfterCoolDown(t *testing.T) {
+	primary := &fakeMailer{err: errors.New("network timeout")}
+	fallback := &fakeMailer{}
+
+	chain := NewChain(ChainConfig{FailureThreshold: 1, CoolDown: 20 * time.Millisecond}, map[string]Mailer{
+		"resend": primary,
+		"smtp":   fallback,
+	}, []string{"resend", "smtp"})
+
+	if err := chain.Send(newTestMessage()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	if err := chain.Send(newTestMessage()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if primary.calls != 2 {
+		t.Fatalf("Expected the primary provider to be retried after cool-down, got %d calls", primary.calls)
+	}
+}
+
+func TestChainAllProvidersFail(t *testing.T) {
+	primary := &fakeMailer{err: errors.New("boom")}
+	fallback := &fakeMailer{err: errors.New("boom too")}
+
+	chain := NewChain(ChainConfig{}, map[string]Mailer{
+		"resend": primary,
+		"smtp":   fallback,
+	}, []string{"resend", "smtp"})
+
+	if err := chain.Send(newTestMessage()); err == nil {
+		t.Fatal("Expected an error when all providers fail")
+	}
+}
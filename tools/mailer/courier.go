@@ -0,0 +1,454 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/hook"
+)
+
+var _ Mailer = (*Courier)(nil)
+
+// CourierStatus defines the lifecycle states of a [CourierMessage].
+type CourierStatus string
+
+const (
+	CourierStatusQueued  CourierStatus = "queued"
+	CourierStatusSending CourierStatus = "sending"
+	CourierStatusSent    CourierStatus = "sent"
+	CourierStatusFailed  CourierStatus = "failed"
+)
+
+// CourierMessage wraps a [Message] with the bookkeeping fields needed
+// to persist and retry it.
+type CourierMessage struct {
+	Id          string
+	Message     *Message
+	Status      CourierStatus
+	Attempts    int
+	LastError   string
+	NextAttempt time.Time
+	Created     time.Time
+	Updated     time.Time
+}
+
+// CourierStore defines the persistence contract used by [Courier] to
+// durably track queued messages.
+//
+// [MemoryCourierStore] is only suitable for tests, since its queue
+// doesn't survive a process restart. Production apps should use
+// [NewSQLiteCourierStore], which persists the queue in a `_mailerQueue`
+// table alongside the app database so provider outages don't result in
+// lost mail across restarts.
+type CourierStore interface {
+	// Enqueue persists a new message in the "queued" status.
+	Enqueue(m *CourierMessage) error
+
+	// Claim returns up to limit due messages (NextAttempt <= now)
+	// and atomically transitions them to the "sending" status so
+	// that concurrent workers don't pick up the same row twice.
+	Claim(limit int, now time.Time) ([]*CourierMessage, error)
+
+	// Update persists the (possibly updated) state of an already
+	// enqueued message.
+	Update(m *CourierMessage) error
+
+	// Get returns a single message by id.
+	Get(id string) (*CourierMessage, error)
+
+	// List returns all stored messages, mostly used for introspection.
+	List() ([]*CourierMessage, error)
+
+	// Delete removes a message from the store (eg. on cancellation).
+	Delete(id string) error
+}
+
+// CourierBeforeSendEvent is triggered right before a queued message is
+// handed off to the wrapped [Mailer].
+type CourierBeforeSendEvent struct {
+	Message *CourierMessage
+}
+
+// CourierAfterSendEvent is triggered after a queued message has been
+// successfully delivered.
+type CourierAfterSendEvent struct {
+	Message *CourierMessage
+}
+
+// CourierFailureEvent is triggered every time a send attempt fails,
+// including the final attempt that moves the message to "failed".
+type CourierFailureEvent struct {
+	Message *CourierMessage
+	Error   error
+}
+
+// CourierConfig configures the retry behavior of a [Courier].
+type CourierConfig struct {
+	// MaxAttempts is the max number of send attempts before a
+	// message is moved to the "failed" status. Zero falls back to 5.
+	MaxAttempts int
+
+	// TickInterval is how often the worker polls the store for due
+	// messages. Zero falls back to 5 seconds.
+	TickInterval time.Duration
+
+	// BaseBackoff is the base delay used for the exponential backoff
+	// (BaseBackoff * 2^(attempt-1), where attempt is the number of failed
+	// attempts so far, capped at MaxBackoff, plus jitter).
+	// Zero falls back to 5 seconds.
+	BaseBackoff time.Duration
+
+	// MaxBackoff caps the exponential part of the backoff delay; the
+	// jitter (up to 20% of the capped delay) is added on top of it.
+	// Zero falls back to 1 hour.
+	MaxBackoff time.Duration
+}
+
+// Courier wraps a [Mailer] with a persistent, retrying queue so that
+// transient provider outages (eg. Resend 5xx responses or SMTP
+// timeouts) don't result in a dropped email.
+//
+// Courier itself implements [Mailer], so it can be used as a drop-in
+// replacement for any existing mailer — [Courier.Send] only enqueues
+// the message, the actual delivery happens asynchronously once
+// [Courier.Start] is running.
+type Courier struct {
+	onBeforeSend *hook.Hook[*CourierBeforeSendEvent]
+	onAfterSend  *hook.Hook[*CourierAfterSendEvent]
+	onFailure    *hook.Hook[*CourierFailureEvent]
+
+	mailer Mailer
+	store  CourierStore
+	config CourierConfig
+
+	mu      sync.Mutex
+	cancel  context.CancelFunc
+	stopped chan struct{}
+	idSeq   int
+}
+
+// NewCourier creates a new [Courier] wrapping mailer and persisting
+// its queue in store.
+//
+// If store is nil, it defaults to an in-memory [MemoryCourierStore].
+func NewCourier(mailer Mailer, store CourierStore, config CourierConfig) *Courier {
+	if store == nil {
+		store = NewMemoryCourierStore()
+	}
+
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 5
+	}
+
+	if config.TickInterval <= 0 {
+		config.TickInterval = 5 * time.Second
+	}
+
+	if config.BaseBackoff <= 0 {
+		config.BaseBackoff = 5 * time.Second
+	}
+
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = time.Hour
+	}
+
+	return &Courier{
+		mailer: mailer,
+		store:  store,
+		config: config,
+	}
+}
+
+// OnBeforeSend returns the hook triggered before a queued message is
+// handed off to the wrapped [Mailer].
+func (c *Courier) OnBeforeSend() *hook.Hook[*CourierBeforeSendEvent] {
+	if c.onBeforeSend == nil {
+		c.onBeforeSend = &hook.Hook[*CourierBeforeSendEvent]{}
+	}
+	return c.onBeforeSend
+}
+
+// OnAfterSend returns the hook triggered after a queued message has
+// been successfully delivered.
+func (c *Courier) OnAfterSend() *hook.Hook[*CourierAfterSendEvent] {
+	if c.onAfterSend == nil {
+		c.onAfterSend = &hook.Hook[*CourierAfterSendEvent]{}
+	}
+	return c.onAfterSend
+}
+
+// OnFailure returns the hook triggered on every failed send attempt.
+func (c *Courier) OnFailure() *hook.Hook[*CourierFailureEvent] {
+	if c.onFailure == nil {
+		c.onFailure = &hook.Hook[*CourierFailureEvent]{}
+	}
+	return c.onFailure
+}
+
+// Send implements [Mailer] by enqueueing m for async delivery.
+//
+// Use [Courier.SendSync] if you need to bypass the queue and deliver
+// the message immediately using the wrapped [Mailer].
+func (c *Courier) Send(m *Message) error {
+	c.mu.Lock()
+	c.idSeq++
+	id := fmt.Sprintf("courier_%d_%d", time.Now().UnixNano(), c.idSeq)
+	c.mu.Unlock()
+
+	now := time.Now()
+
+	return c.store.Enqueue(&CourierMessage{
+		Id:          id,
+		Message:     m,
+		Status:      CourierStatusQueued,
+		NextAttempt: now,
+		Created:     now,
+		Updated:     now,
+	})
+}
+
+// SendSync bypasses the queue and delivers m immediately using the
+// wrapped [Mailer].
+func (c *Courier) SendSync(m *Message) error {
+	return c.mailer.Send(m)
+}
+
+// Retry resets a failed message back to "queued" so it is picked up
+// again on the next tick.
+func (c *Courier) Retry(id string) error {
+	cm, err := c.store.Get(id)
+	if err != nil {
+		return err
+	}
+
+	cm.Status = CourierStatusQueued
+	cm.NextAttempt = time.Now()
+	cm.Updated = time.Now()
+
+	return c.store.Update(cm)
+}
+
+// Cancel removes a queued or failed message from the store.
+func (c *Courier) Cancel(id string) error {
+	return c.store.Delete(id)
+}
+
+// List returns all the messages currently tracked by the store.
+func (c *Courier) List() ([]*CourierMessage, error) {
+	return c.store.List()
+}
+
+// Get returns a single tracked message by id.
+func (c *Courier) Get(id string) (*CourierMessage, error) {
+	return c.store.Get(id)
+}
+
+// Start begins polling the store for due messages and processing them
+// until ctx is cancelled or [Courier.Stop] is called.
+func (c *Courier) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	c.mu.Lock()
+	c.cancel = cancel
+	c.stopped = make(chan struct{})
+	c.mu.Unlock()
+
+	ticker := time.NewTicker(c.config.TickInterval)
+
+	go func() {
+		defer ticker.Stop()
+		defer close(c.stopped)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.processDue()
+			}
+		}
+	}()
+}
+
+// Stop cancels the background worker started with [Courier.Start] and
+// blocks until it has fully exited.
+func (c *Courier) Stop() {
+	c.mu.Lock()
+	cancel := c.cancel
+	stopped := c.stopped
+	c.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+
+	cancel()
+
+	if stopped != nil {
+		<-stopped
+	}
+}
+
+func (c *Courier) processDue() {
+	due, err := c.store.Claim(50, time.Now())
+	if err != nil || len(due) == 0 {
+		return
+	}
+
+	for _, cm := range due {
+		c.processOne(cm)
+	}
+}
+
+func (c *Courier) processOne(cm *CourierMessage) {
+	if c.onBeforeSend != nil {
+		_ = c.onBeforeSend.Trigger(&CourierBeforeSendEvent{Message: cm})
+	}
+
+	err := c.mailer.Send(cm.Message)
+
+	cm.Attempts++
+	cm.Updated = time.Now()
+
+	if err == nil {
+		cm.Status = CourierStatusSent
+		cm.LastError = ""
+		_ = c.store.Update(cm)
+
+		if c.onAfterSend != nil {
+			_ = c.onAfterSend.Trigger(&CourierAfterSendEvent{Message: cm})
+		}
+
+		return
+	}
+
+	cm.LastError = err.Error()
+
+	if cm.Attempts >= c.config.MaxAttempts {
+		cm.Status = CourierStatusFailed
+	} else {
+		cm.Status = CourierStatusQueued
+		cm.NextAttempt = time.Now().Add(c.backoff(cm.Attempts))
+	}
+
+	_ = c.store.Update(cm)
+
+	if c.onFailure != nil {
+		_ = c.onFailure.Trigger(&CourierFailureEvent{Message: cm, Error: err})
+	}
+}
+
+// backoff computes an exponential delay for the given attempt count,
+// capped at MaxBackoff, plus a random jitter of up to 20% of that
+// capped delay.
+func (c *Courier) backoff(attempt int) time.Duration {
+	delay := float64(c.config.BaseBackoff) * math.Pow(2, float64(attempt-1))
+
+	if delay > float64(c.config.MaxBackoff) {
+		delay = float64(c.config.MaxBackoff)
+	}
+
+	jitter := rand.Float64() * delay * 0.2
+
+	return time.Duration(delay + jitter)
+}
+
+// MemoryCourierStore is a non-persistent [CourierStore] implementation
+// kept in process memory, so its queue doesn't survive a process
+// restart. It is the default store used by [NewCourier] and is mainly
+// intended for tests.
+type MemoryCourierStore struct {
+	mu       sync.Mutex
+	messages map[string]*CourierMessage
+}
+
+// NewMemoryCourierStore creates a new empty [MemoryCourierStore].
+func NewMemoryCourierStore() *MemoryCourierStore {
+	return &MemoryCourierStore{
+		messages: make(map[string]*CourierMessage),
+	}
+}
+
+func (s *MemoryCourierStore) Enqueue(m *CourierMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages[m.Id] = m
+
+	return nil
+}
+
+func (s *MemoryCourierStore) Claim(limit int, now time.Time) ([]*CourierMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var claimed []*CourierMessage
+
+	for _, m := range s.messages {
+		if len(claimed) >= limit {
+			break
+		}
+
+		if m.Status != CourierStatusQueued || m.NextAttempt.After(now) {
+			continue
+		}
+
+		m.Status = CourierStatusSending
+		m.Updated = now
+
+		claimed = append(claimed, m)
+	}
+
+	return claimed, nil
+}
+
+func (s *MemoryCourierStore) Update(m *CourierMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.messages[m.Id]; !ok {
+		return errors.New("missing courier message with id " + m.Id)
+	}
+
+	s.messages[m.Id] = m
+
+	return nil
+}
+
+func (s *MemoryCourierStore) Get(id string) (*CourierMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, ok := s.messages[id]
+	if !ok {
+		return nil, errors.New("missing courier message with id " + id)
+	}
+
+	return m, nil
+}
+
+func (s *MemoryCourierStore) List() ([]*CourierMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]*CourierMessage, 0, len(s.messages))
+	for _, m := range s.messages {
+		result = append(result, m)
+	}
+
+	return result, nil
+}
+
+func (s *MemoryCourierStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.messages, id)
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package mailer
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// CourierAPI exposes the [Courier] queue over HTTP so that an admin UI
+// can list, inspect, retry and cancel queued messages.
+//
+// It is a plain [http.Handler] so it can be mounted under any prefix
+// by the app's router, eg.:
+//
+//	apiGroup.Any("/mailer/queue/*", echo.WrapHandler(
+//		http.StripPrefix("/api/mailer/queue", mailer.NewCourierAPI(courier, mailer.WithCourierAPIAuth(requireSuperuserAuth))),
+//	))
+//
+// CourierAPI performs NO authentication or authorization of its own
+// beyond calling the Authorize func supplied via [WithCourierAPIAuth].
+// Queued messages can include recipient addresses and full email
+// bodies, and retry/cancel let a caller interfere with arbitrary
+// pending mail, so an Authorize func that restricts access to
+// superusers/admins (eg. the app's existing admin-auth middleware)
+// MUST be supplied before mounting this handler on a real app router -
+// without one, every request is rejected with 403 rather than silently
+// served.
+type CourierAPI struct {
+	courier   *Courier
+	authorize func(r *http.Request) error
+}
+
+// CourierAPIOption defines a function that can be used to configure a
+// [CourierAPI] instance created with [NewCourierAPI].
+type CourierAPIOption func(a *CourierAPI)
+
+// WithCourierAPIAuth sets the func used to authorize every incoming
+// request before it reaches the underlying [Courier]. authorize should
+// return a non-nil error to reject the request (eg. if the caller
+// isn't an authenticated superuser).
+func WithCourierAPIAuth(authorize func(r *http.Request) error) CourierAPIOption {
+	return func(a *CourierAPI) {
+		a.authorize = authorize
+	}
+}
+
+// NewCourierAPI creates a new [CourierAPI] for courier.
+//
+// An Authorize func must be supplied via [WithCourierAPIAuth], or
+// every request is rejected with 403 Forbidden - see the [CourierAPI]
+// doc for why this isn't open by default.
+func NewCourierAPI(courier *Courier, opts ...CourierAPIOption) *CourierAPI {
+	a := &CourierAPI{courier: courier}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
+}
+
+// ServeHTTP implements [http.Handler].
+//
+// Routes (relative to the mount prefix):
+//
+//	GET    /            list all queued messages
+//	GET    /{id}        inspect a single queued message
+//	POST   /{id}/retry  requeue a failed message
+//	POST   /{id}/cancel cancel a queued or failed message
+func (a *CourierAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if a.authorize == nil {
+		http.Error(w, "CourierAPI has no authorize func configured; see NewCourierAPI/WithCourierAPIAuth", http.StatusForbidden)
+		return
+	}
+
+	if err := a.authorize(r); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	path := strings.Trim(r.URL.Path, "/")
+
+	switch {
+	case path == "" && r.Method == http.MethodGet:
+		a.list(w, r)
+	case strings.HasSuffix(path, "/retry") && r.Method == http.MethodPost:
+		a.retry(w, strings.TrimSuffix(path, "/retry"))
+	case strings.HasSuffix(path, "/cancel") && r.Method == http.MethodPost:
+		a.cancel(w, strings.TrimSuffix(path, "/cancel"))
+	case path != "" && r.Method == http.MethodGet:
+		a.inspect(w, path)
+	default:
+		http.Error(w, "not found", http.StatusNotFound)
+	}
+}
+
+func (a *CourierAPI) list(w http.ResponseWriter, r *http.Request) {
+	messages, err := a.courier.List()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, messages)
+}
+
+func (a *CourierAPI) inspect(w http.ResponseWriter, id string) {
+	message, err := a.courier.Get(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, message)
+}
+
+func (a *CourierAPI) retry(w http.ResponseWriter, id string) {
+	if err := a.courier.Retry(id); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *CourierAPI) cancel(w http.ResponseWriter, id string) {
+	if err := a.courier.Cancel(id); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(data)
+}
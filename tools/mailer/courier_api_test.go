@@ -0,0 +1,136 @@
+package mailer
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func allowAll(r *http.Request) error { return nil }
+
+func TestCourierAPIRejectsWithoutAuthorize(t *testing.T) {
+	store := NewMemoryCourierStore()
+	courier := NewCourier(&testMailer{}, store, CourierConfig{})
+	api := NewCourierAPI(courier)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+
+	api.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("Expected status %d when no authorize func is configured, got %d", http.StatusForbidden, rec.Code)
+	}
+}
+
+func TestCourierAPIRejectsFailedAuthorize(t *testing.T) {
+	store := NewMemoryCourierStore()
+	courier := NewCourier(&testMailer{}, store, CourierConfig{})
+	api := NewCourierAPI(courier, WithCourierAPIAuth(func(r *http.Request) error {
+		return errors.New("not a superuser")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+
+	api.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("Expected status %d when authorize rejects the request, got %d", http.StatusForbidden, rec.Code)
+	}
+}
+
+func TestCourierAPIList(t *testing.T) {
+	store := NewMemoryCourierStore()
+	courier := NewCourier(&testMailer{}, store, CourierConfig{})
+	api := NewCourierAPI(courier, WithCourierAPIAuth(allowAll))
+
+	if err := courier.Send(newTestMessage()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+
+	api.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+
+	var messages []*CourierMessage
+	if err := json.Unmarshal(rec.Body.Bytes(), &messages); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+}
+
+func TestCourierAPIInspectRetryCancel(t *testing.T) {
+	store := NewMemoryCourierStore()
+	courier := NewCourier(&testMailer{}, store, CourierConfig{})
+	api := NewCourierAPI(courier, WithCourierAPIAuth(allowAll))
+
+	if err := courier.Send(newTestMessage()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	list, _ := courier.List()
+	id := list[0].Id
+
+	t.Run("inspect", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/"+id, nil)
+		rec := httptest.NewRecorder()
+
+		api.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d", http.StatusOK, rec.Code)
+		}
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/"+id+"/retry", nil)
+		rec := httptest.NewRecorder()
+
+		api.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("Expected status %d, got %d", http.StatusNoContent, rec.Code)
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/"+id+"/cancel", nil)
+		rec := httptest.NewRecorder()
+
+		api.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("Expected status %d, got %d", http.StatusNoContent, rec.Code)
+		}
+
+		if _, err := store.Get(id); err == nil {
+			t.Fatal("Expected the message to be cancelled/removed")
+		}
+	})
+}
+
+func TestCourierAPIInspectMissing(t *testing.T) {
+	store := NewMemoryCourierStore()
+	courier := NewCourier(&testMailer{}, store, CourierConfig{})
+	api := NewCourierAPI(courier, WithCourierAPIAuth(allowAll))
+
+	req := httptest.NewRequest(http.MethodGet, "/missing", nil)
+	rec := httptest.NewRecorder()
+
+	api.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("Expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+}
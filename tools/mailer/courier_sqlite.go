@@ -0,0 +1,283 @@
+package mailer
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"time"
+)
+
+var _ CourierStore = (*SQLiteCourierStore)(nil)
+
+// mailerQueueTable is the name of the table [SQLiteCourierStore]
+// persists its queue in, created alongside the rest of the app tables
+// in the same SQLite database.
+const mailerQueueTable = "_mailerQueue"
+
+// SQLiteCourierStore is a [CourierStore] implementation backed by a
+// `_mailerQueue` table in a SQLite database, so that the queue
+// survives app/process restarts.
+//
+// It is the store [NewCourier] should be given in production, eg.:
+//
+//	store, err := mailer.NewSQLiteCourierStore(app.DB())
+//	courier := mailer.NewCourier(mailClient, store, mailer.CourierConfig{})
+//
+// Note that [Message.Attachments] and [Message.InlineAttachments] are
+// in-memory io.Reader values and are intentionally NOT persisted.
+// Since [SQLiteCourierStore.Claim] decodes every message from the
+// table, messages queued through this store are always delivered
+// without their attachments, whether or not the process restarted in
+// between. Callers that need attachments should upload them to blob
+// storage beforehand and reference them by URL in the message body
+// instead.
+type SQLiteCourierStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteCourierStore creates a new [SQLiteCourierStore] using db,
+// ensuring the backing table exists.
+func NewSQLiteCourierStore(db *sql.DB) (*SQLiteCourierStore, error) {
+	s := &SQLiteCourierStore{db: db}
+
+	if err := s.ensureSchema(); err != nil {
+		return nil, fmt.Errorf("failed to initialize the %s table: %w", mailerQueueTable, err)
+	}
+
+	return s, nil
+}
+
+func (s *SQLiteCourierStore) ensureSchema() error {
+	_, err := s.db.Exec(fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			id           TEXT PRIMARY KEY,
+			message      TEXT NOT NULL,
+			status       TEXT NOT NULL,
+			attempts     INTEGER NOT NULL DEFAULT 0,
+			last_error   TEXT NOT NULL DEFAULT '',
+			next_attempt DATETIME NOT NULL,
+			created      DATETIME NOT NULL,
+			updated      DATETIME NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_%s_due ON %s (status, next_attempt);
+	`, mailerQueueTable, mailerQueueTable, mailerQueueTable))
+
+	return err
+}
+
+// persistedMessage is the JSON-serializable subset of [Message] that
+// is safe to persist (see the [SQLiteCourierStore] doc for why
+// attachments are excluded).
+type persistedMessage struct {
+	From           mail.Address
+	To             []mail.Address
+	Cc             []mail.Address
+	Bcc            []mail.Address
+	Subject        string
+	HTML           string
+	Text           string
+	Headers        map[string]string
+	IdempotencyKey string
+}
+
+func encodeMessage(m *Message) (string, error) {
+	raw, err := json.Marshal(persistedMessage{
+		From:           m.From,
+		To:             m.To,
+		Cc:             m.Cc,
+		Bcc:            m.Bcc,
+		Subject:        m.Subject,
+		HTML:           m.HTML,
+		Text:           m.Text,
+		Headers:        m.Headers,
+		IdempotencyKey: m.IdempotencyKey,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(raw), nil
+}
+
+func decodeMessage(raw string) (*Message, error) {
+	var pm persistedMessage
+	if err := json.Unmarshal([]byte(raw), &pm); err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		From:           pm.From,
+		To:             pm.To,
+		Cc:             pm.Cc,
+		Bcc:            pm.Bcc,
+		Subject:        pm.Subject,
+		HTML:           pm.HTML,
+		Text:           pm.Text,
+		Headers:        pm.Headers,
+		IdempotencyKey: pm.IdempotencyKey,
+	}, nil
+}
+
+func (s *SQLiteCourierStore) Enqueue(m *CourierMessage) error {
+	encoded, err := encodeMessage(m.Message)
+	if err != nil {
+		return fmt.Errorf("failed to encode courier message: %w", err)
+	}
+
+	_, err = s.db.Exec(
+		fmt.Sprintf(`INSERT INTO %s (id, message, status, attempts, last_error, next_attempt, created, updated)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`, mailerQueueTable),
+		m.Id, encoded, m.Status, m.Attempts, m.LastError, m.NextAttempt, m.Created, m.Updated,
+	)
+
+	return err
+}
+
+func (s *SQLiteCourierStore) Claim(limit int, now time.Time) ([]*CourierMessage, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(
+		fmt.Sprintf(`SELECT id FROM %s WHERE status = ? AND next_attempt <= ? ORDER BY next_attempt LIMIT ?`, mailerQueueTable),
+		CourierStatusQueued, now, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	claimed := make([]*CourierMessage, 0, len(ids))
+
+	for _, id := range ids {
+		_, err = tx.Exec(
+			fmt.Sprintf(`UPDATE %s SET status = ?, updated = ? WHERE id = ?`, mailerQueueTable),
+			CourierStatusSending, now, id,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		cm, err := s.scanOne(tx.QueryRow(fmt.Sprintf(`SELECT %s FROM %s WHERE id = ?`, courierColumns, mailerQueueTable), id))
+		if err != nil {
+			return nil, err
+		}
+
+		claimed = append(claimed, cm)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return claimed, nil
+}
+
+func (s *SQLiteCourierStore) Update(m *CourierMessage) error {
+	encoded, err := encodeMessage(m.Message)
+	if err != nil {
+		return fmt.Errorf("failed to encode courier message: %w", err)
+	}
+
+	res, err := s.db.Exec(
+		fmt.Sprintf(`UPDATE %s SET message = ?, status = ?, attempts = ?, last_error = ?, next_attempt = ?, updated = ? WHERE id = ?`, mailerQueueTable),
+		encoded, m.Status, m.Attempts, m.LastError, m.NextAttempt, m.Updated, m.Id,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return errors.New("missing courier message with id " + m.Id)
+	}
+
+	return nil
+}
+
+const courierColumns = "id, message, status, attempts, last_error, next_attempt, created, updated"
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func (s *SQLiteCourierStore) scanOne(row rowScanner) (*CourierMessage, error) {
+	var (
+		cm      CourierMessage
+		encoded string
+	)
+
+	err := row.Scan(&cm.Id, &encoded, &cm.Status, &cm.Attempts, &cm.LastError, &cm.NextAttempt, &cm.Created, &cm.Updated)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("missing courier message")
+		}
+		return nil, err
+	}
+
+	m, err := decodeMessage(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode courier message: %w", err)
+	}
+	cm.Message = m
+
+	return &cm, nil
+}
+
+func (s *SQLiteCourierStore) Get(id string) (*CourierMessage, error) {
+	row := s.db.QueryRow(fmt.Sprintf(`SELECT %s FROM %s WHERE id = ?`, courierColumns, mailerQueueTable), id)
+
+	cm, err := s.scanOne(row)
+	if err != nil {
+		return nil, fmt.Errorf("missing courier message with id %s: %w", id, err)
+	}
+
+	return cm, nil
+}
+
+func (s *SQLiteCourierStore) List() ([]*CourierMessage, error) {
+	rows, err := s.db.Query(fmt.Sprintf(`SELECT %s FROM %s ORDER BY created`, courierColumns, mailerQueueTable))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*CourierMessage
+
+	for rows.Next() {
+		cm, err := s.scanOne(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, cm)
+	}
+
+	return result, rows.Err()
+}
+
+func (s *SQLiteCourierStore) Delete(id string) error {
+	_, err := s.db.Exec(fmt.Sprintf(`DELETE FROM %s WHERE id = ?`, mailerQueueTable), id)
+	return err
+}
@@ -0,0 +1,237 @@
+package mailer
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+func newTestSQLiteStore(t *testing.T) *SQLiteCourierStore {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open sqlite db: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	store, err := NewSQLiteCourierStore(db)
+	if err != nil {
+		t.Fatalf("Failed to create SQLiteCourierStore: %v", err)
+	}
+
+	return store
+}
+
+func TestSQLiteCourierStoreEnqueueAndGet(t *testing.T) {
+	store := newTestSQLiteStore(t)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	cm := &CourierMessage{
+		Id:          "msg1",
+		Message:     newTestMessage(),
+		Status:      CourierStatusQueued,
+		NextAttempt: now,
+		Created:     now,
+		Updated:     now,
+	}
+
+	if err := store.Enqueue(cm); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	fetched, err := store.Get("msg1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if fetched.Status != CourierStatusQueued {
+		t.Fatalf("Expected status %q, got %q", CourierStatusQueued, fetched.Status)
+	}
+
+	if fetched.Message.Subject != cm.Message.Subject {
+		t.Fatalf("Expected subject %q, got %q", cm.Message.Subject, fetched.Message.Subject)
+	}
+}
+
+func TestSQLiteCourierStoreClaimIsAtomic(t *testing.T) {
+	store := newTestSQLiteStore(t)
+
+	now := time.Now().UTC()
+
+	for i := 0; i < 3; i++ {
+		cm := &CourierMessage{
+			Id:          string(rune('a' + i)),
+			Message:     newTestMessage(),
+			Status:      CourierStatusQueued,
+			NextAttempt: now.Add(-time.Minute),
+			Created:     now,
+			Updated:     now,
+		}
+		if err := store.Enqueue(cm); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	claimed, err := store.Claim(2, now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(claimed) != 2 {
+		t.Fatalf("Expected 2 claimed messages, got %d", len(claimed))
+	}
+
+	for _, cm := range claimed {
+		if cm.Status != CourierStatusSending {
+			t.Fatalf("Expected claimed message status %q, got %q", CourierStatusSending, cm.Status)
+		}
+	}
+
+	remaining, err := store.Claim(10, now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(remaining) != 1 {
+		t.Fatalf("Expected 1 remaining queued message, got %d", len(remaining))
+	}
+}
+
+func TestSQLiteCourierStoreUpdateAndDelete(t *testing.T) {
+	store := newTestSQLiteStore(t)
+
+	now := time.Now().UTC()
+	cm := &CourierMessage{
+		Id:          "msg1",
+		Message:     newTestMessage(),
+		Status:      CourierStatusQueued,
+		NextAttempt: now,
+		Created:     now,
+		Updated:     now,
+	}
+
+	if err := store.Enqueue(cm); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	cm.Status = CourierStatusFailed
+	cm.LastError = "boom"
+
+	if err := store.Update(cm); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	fetched, err := store.Get("msg1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if fetched.Status != CourierStatusFailed || fetched.LastError != "boom" {
+		t.Fatalf("Expected updated fields to persist, got %+v", fetched)
+	}
+
+	if err := store.Delete("msg1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := store.Get("msg1"); err == nil {
+		t.Fatal("Expected the message to be deleted")
+	}
+}
+
+func TestSQLiteCourierStoreIdempotencyKeyStableAcrossRetries(t *testing.T) {
+	store := newTestSQLiteStore(t)
+
+	now := time.Now().UTC()
+	if err := store.Enqueue(&CourierMessage{
+		Id:          "msg1",
+		Message:     newTestMessage(),
+		Status:      CourierStatusQueued,
+		NextAttempt: now,
+		Created:     now,
+		Updated:     now,
+	}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Simulates the first send attempt: no key has been generated yet.
+	claimed, err := store.Claim(10, now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(claimed) != 1 || claimed[0].Message.IdempotencyKey != "" {
+		t.Fatalf("Expected the first claim to carry no idempotency key, got %+v", claimed)
+	}
+
+	// Simulates the provider generating and caching a key onto the
+	// in-memory Message during that failed send attempt, then the
+	// courier persisting the message back after the attempt.
+	key := "generated-key-1"
+	claimed[0].Message.IdempotencyKey = key
+	claimed[0].Status = CourierStatusQueued
+	claimed[0].NextAttempt = now
+	if err := store.Update(claimed[0]); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// A later retry must decode the same key back, not an empty one,
+	// or the provider will treat the retry as a brand-new send.
+	retried, err := store.Claim(10, now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(retried) != 1 || retried[0].Message.IdempotencyKey != key {
+		t.Fatalf("Expected the idempotency key %q to survive the retry round-trip, got %+v", key, retried)
+	}
+}
+
+func TestSQLiteCourierStoreSurvivesReopen(t *testing.T) {
+	path := t.TempDir() + "/courier.db"
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("Failed to open sqlite db: %v", err)
+	}
+
+	store, err := NewSQLiteCourierStore(db)
+	if err != nil {
+		t.Fatalf("Failed to create SQLiteCourierStore: %v", err)
+	}
+
+	now := time.Now().UTC()
+	if err := store.Enqueue(&CourierMessage{
+		Id:          "msg1",
+		Message:     newTestMessage(),
+		Status:      CourierStatusQueued,
+		NextAttempt: now,
+		Created:     now,
+		Updated:     now,
+	}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	db.Close()
+
+	reopened, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("Failed to reopen sqlite db: %v", err)
+	}
+	defer reopened.Close()
+
+	reopenedStore, err := NewSQLiteCourierStore(reopened)
+	if err != nil {
+		t.Fatalf("Expected no error reinitializing schema, got %v", err)
+	}
+
+	fetched, err := reopenedStore.Get("msg1")
+	if err != nil {
+		t.Fatalf("Expected the queued message to survive reopening the database, got %v", err)
+	}
+
+	if fetched.Id != "msg1" {
+		t.Fatalf("Expected message id %q, got %q", "msg1", fetched.Id)
+	}
+}
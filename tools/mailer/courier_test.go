@@ -0,0 +1,210 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testMailer struct {
+	failUntil int32
+	calls     int32
+}
+
+func (m *testMailer) Send(msg *Message) error {
+	n := atomic.AddInt32(&m.calls, 1)
+	if n <= m.failUntil {
+		return errors.New("simulated failure")
+	}
+	return nil
+}
+
+func newTestMessage() *Message {
+	return &Message{
+		From:    mail.Address{Address: "sender@example.com"},
+		To:      []mail.Address{{Address: "to@example.com"}},
+		Subject: "Test",
+		HTML:    "<p>Test</p>",
+	}
+}
+
+func TestCourierSendEnqueuesMessage(t *testing.T) {
+	store := NewMemoryCourierStore()
+	courier := NewCourier(&testMailer{}, store, CourierConfig{})
+
+	if err := courier.Send(newTestMessage()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	list, err := courier.List()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(list) != 1 {
+		t.Fatalf("Expected 1 queued message, got %d", len(list))
+	}
+
+	if list[0].Status != CourierStatusQueued {
+		t.Fatalf("Expected status %q, got %q", CourierStatusQueued, list[0].Status)
+	}
+}
+
+func TestCourierProcessesQueuedMessages(t *testing.T) {
+	store := NewMemoryCourierStore()
+	mailer := &testMailer{}
+	courier := NewCourier(mailer, store, CourierConfig{TickInterval: 10 * time.Millisecond})
+
+	var sent int32
+	courier.OnAfterSend().BindFunc(func(e *CourierAfterSendEvent) error {
+		atomic.AddInt32(&sent, 1)
+		return nil
+	})
+
+	if err := courier.Send(newTestMessage()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	courier.Start(ctx)
+	defer courier.Stop()
+
+	deadline := time.Now().Add(500 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		if atomic.LoadInt32(&sent) == 1 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if atomic.LoadInt32(&sent) != 1 {
+		t.Fatal("Expected the message to be sent")
+	}
+}
+
+func TestCourierRetriesOnFailure(t *testing.T) {
+	store := NewMemoryCourierStore()
+	mailer := &testMailer{failUntil: 1}
+	courier := NewCourier(mailer, store, CourierConfig{
+		TickInterval: 10 * time.Millisecond,
+		BaseBackoff:  10 * time.Millisecond,
+		MaxBackoff:   20 * time.Millisecond,
+	})
+
+	var failures int32
+	courier.OnFailure().BindFunc(func(e *CourierFailureEvent) error {
+		atomic.AddInt32(&failures, 1)
+		return nil
+	})
+
+	if err := courier.Send(newTestMessage()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	courier.Start(ctx)
+	defer courier.Stop()
+
+	deadline := time.Now().Add(800 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		if atomic.LoadInt32(&failures) >= 1 && atomic.LoadInt32(&mailer.calls) >= 2 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if atomic.LoadInt32(&mailer.calls) < 2 {
+		t.Fatalf("Expected at least 2 send attempts, got %d", mailer.calls)
+	}
+}
+
+func TestCourierMaxAttemptsMovesToFailed(t *testing.T) {
+	store := NewMemoryCourierStore()
+	mailer := &testMailer{failUntil: 100}
+	courier := NewCourier(mailer, store, CourierConfig{
+		MaxAttempts:  2,
+		TickInterval: 10 * time.Millisecond,
+		BaseBackoff:  5 * time.Millisecond,
+		MaxBackoff:   10 * time.Millisecond,
+	})
+
+	if err := courier.Send(newTestMessage()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	courier.Start(ctx)
+	defer courier.Stop()
+
+	deadline := time.Now().Add(time.Second)
+	var final CourierStatus
+	for time.Now().Before(deadline) {
+		list, _ := courier.List()
+		if len(list) == 1 && list[0].Status == CourierStatusFailed {
+			final = list[0].Status
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if final != CourierStatusFailed {
+		t.Fatalf("Expected message to end up in %q status, got %q", CourierStatusFailed, final)
+	}
+}
+
+func TestCourierRetryRequeuesFailedMessage(t *testing.T) {
+	store := NewMemoryCourierStore()
+	courier := NewCourier(&testMailer{}, store, CourierConfig{})
+
+	if err := courier.Send(newTestMessage()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	list, _ := courier.List()
+	cm := list[0]
+	cm.Status = CourierStatusFailed
+	if err := store.Update(cm); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := courier.Retry(cm.Id); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	updated, err := store.Get(cm.Id)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if updated.Status != CourierStatusQueued {
+		t.Fatalf("Expected status %q, got %q", CourierStatusQueued, updated.Status)
+	}
+}
+
+func TestCourierCancelRemovesMessage(t *testing.T) {
+	store := NewMemoryCourierStore()
+	courier := NewCourier(&testMailer{}, store, CourierConfig{})
+
+	if err := courier.Send(newTestMessage()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	list, _ := courier.List()
+
+	if err := courier.Cancel(list[0].Id); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := store.Get(list[0].Id); err == nil {
+		t.Fatal("Expected the message to be removed from the store")
+	}
+}
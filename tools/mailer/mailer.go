@@ -0,0 +1,53 @@
+package mailer
+
+import (
+	"io"
+	"net/mail"
+
+	"github.com/pocketbase/pocketbase/tools/hook"
+)
+
+// Mailer defines a base mail client interface.
+type Mailer interface {
+	// Send sends an email with the provided Message.
+	Send(message *Message) error
+}
+
+// SendInterceptor defines an optional [Mailer] interface for
+// intercepting the mail send call.
+type SendInterceptor interface {
+	// OnSend should return the hook that is triggered on every Send call.
+	OnSend() *hook.Hook[*SendEvent]
+}
+
+// SendEvent defines the on send mailer client event.
+type SendEvent struct {
+	Message *Message
+}
+
+// Message defines a generic email message struct.
+type Message struct {
+	From              mail.Address
+	To                []mail.Address
+	Bcc               []mail.Address
+	Cc                []mail.Address
+	Subject           string
+	HTML              string
+	Text              string
+	Headers           map[string]string
+	Attachments       map[string]io.Reader
+	InlineAttachments map[string]io.Reader
+
+	// IdempotencyKey, when set, is forwarded as-is to providers that
+	// support safe-retry semantics (eg. Resend's `Idempotency-Key`
+	// header), guaranteeing at-most-once delivery for a given logical
+	// send even if the underlying HTTP request is retried at the
+	// network level or by a [Courier].
+	//
+	// Leave empty to let the provider generate one (see [ResendClient]);
+	// the generated key is stored back on this field, so repeated
+	// [Mailer.Send] calls with the same Message value reuse it.
+	// Two separate Message values are always treated as two separate
+	// sends regardless of whether their content is identical.
+	IdempotencyKey string
+}
@@ -25,6 +25,72 @@ type ResendClient struct {
 
 	// APIKey is the Resend API key for authentication.
 	APIKey string
+
+	// HTTPClient is the client used to perform the outbound API requests.
+	//
+	// If not set, it defaults to [http.DefaultClient].
+	HTTPClient *http.Client
+
+	// Endpoint is the Resend API endpoint used to send the emails.
+	//
+	// If not set, it defaults to the resendAPIEndpoint const
+	// (https://api.resend.com/emails).
+	//
+	// It is mostly useful for tests or for routing the requests
+	// through a self-hosted Resend-compatible proxy.
+	Endpoint string
+}
+
+// ResendClientOption defines a function that can be used to configure
+// a [ResendClient] instance created with [NewResendClient].
+type ResendClientOption func(c *ResendClient)
+
+// WithResendHTTPClient sets a custom HTTP client for the outbound
+// Resend API requests (eg. to inject a custom [http.Transport] for
+// retries, observability or mTLS, or to route through an outbound proxy).
+func WithResendHTTPClient(client *http.Client) ResendClientOption {
+	return func(c *ResendClient) {
+		c.HTTPClient = client
+	}
+}
+
+// WithResendEndpoint overrides the default Resend API endpoint.
+func WithResendEndpoint(endpoint string) ResendClientOption {
+	return func(c *ResendClient) {
+		c.Endpoint = endpoint
+	}
+}
+
+// NewResendClient creates a new [ResendClient] with the specified API
+// key and optional configuration options.
+func NewResendClient(apiKey string, opts ...ResendClientOption) *ResendClient {
+	c := &ResendClient{APIKey: apiKey}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// httpClient returns the configured HTTPClient or [http.DefaultClient]
+// as fallback.
+func (c *ResendClient) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+
+	return http.DefaultClient
+}
+
+// endpoint returns the configured Endpoint or the default resendAPIEndpoint
+// as fallback.
+func (c *ResendClient) endpoint() string {
+	if c.Endpoint != "" {
+		return c.Endpoint
+	}
+
+	return resendAPIEndpoint
 }
 
 // OnSend implements [mailer.SendInterceptor] interface.
@@ -73,12 +139,67 @@ type resendErrorResponse struct {
 	Name       string `json:"name"`
 }
 
+// ResendAPIError is returned whenever the Resend API responds with a
+// non-2xx status code, allowing callers (eg. [Chain]) to distinguish
+// auth/config errors from transient ones without parsing error strings.
+type ResendAPIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *ResendAPIError) Error() string {
+	return fmt.Sprintf("resend API error (%d): %s", e.StatusCode, e.Message)
+}
+
 func (c *ResendClient) send(m *Message) error {
 	if c.APIKey == "" {
 		return errors.New("resend API key is required")
 	}
 
-	// Build the payload
+	payload, err := c.buildPayload(m)
+	if err != nil {
+		return err
+	}
+
+	// Marshal payload to JSON
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal resend payload: %w", err)
+	}
+
+	// Create HTTP request
+	req, err := http.NewRequest(http.MethodPost, c.endpoint(), bytes.NewReader(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("failed to create resend request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", idempotencyKey(m))
+
+	// Send request
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send resend request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Check response status
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		var errResp resendErrorResponse
+		if json.Unmarshal(body, &errResp) == nil && errResp.Message != "" {
+			return &ResendAPIError{StatusCode: resp.StatusCode, Message: errResp.Message}
+		}
+		return &ResendAPIError{StatusCode: resp.StatusCode, Message: string(body)}
+	}
+
+	return nil
+}
+
+// buildPayload converts m into the Resend API JSON payload shape,
+// shared between the single [ResendClient.send] and [ResendClient.SendBatch] paths.
+func (c *ResendClient) buildPayload(m *Message) (resendPayload, error) {
 	payload := resendPayload{
 		From:    m.From.String(),
 		To:      addressesToStrings(m.To, true),
@@ -133,7 +254,7 @@ func (c *ResendClient) send(m *Message) error {
 		for name, data := range m.Attachments {
 			attachment, err := c.prepareAttachment(name, data)
 			if err != nil {
-				return fmt.Errorf("failed to prepare attachment %s: %w", name, err)
+				return resendPayload{}, fmt.Errorf("failed to prepare attachment %s: %w", name, err)
 			}
 			payload.Attachments = append(payload.Attachments, attachment)
 		}
@@ -144,46 +265,13 @@ func (c *ResendClient) send(m *Message) error {
 		for name, data := range m.InlineAttachments {
 			attachment, err := c.prepareAttachment(name, data)
 			if err != nil {
-				return fmt.Errorf("failed to prepare inline attachment %s: %w", name, err)
+				return resendPayload{}, fmt.Errorf("failed to prepare inline attachment %s: %w", name, err)
 			}
 			payload.Attachments = append(payload.Attachments, attachment)
 		}
 	}
 
-	// Marshal payload to JSON
-	jsonPayload, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("failed to marshal resend payload: %w", err)
-	}
-
-	// Create HTTP request
-	req, err := http.NewRequest(http.MethodPost, resendAPIEndpoint, bytes.NewReader(jsonPayload))
-	if err != nil {
-		return fmt.Errorf("failed to create resend request: %w", err)
-	}
-
-	req.Header.Set("Authorization", "Bearer "+c.APIKey)
-	req.Header.Set("Content-Type", "application/json")
-
-	// Send request
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to send resend request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Check response status
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		body, _ := io.ReadAll(resp.Body)
-		var errResp resendErrorResponse
-		if json.Unmarshal(body, &errResp) == nil && errResp.Message != "" {
-			return fmt.Errorf("resend API error (%d): %s", resp.StatusCode, errResp.Message)
-		}
-		return fmt.Errorf("resend API error (%d): %s", resp.StatusCode, string(body))
-	}
-
-	return nil
+	return payload, nil
 }
 
 // prepareAttachment reads the attachment data and converts it to a resendAttachment.
@@ -209,4 +297,3 @@ func (c *ResendClient) prepareAttachment(name string, data io.Reader) (resendAtt
 		ContentType: mimeType,
 	}, nil
 }
-
@@ -0,0 +1,142 @@
+package mailer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// maxBatchMessages is the max number of messages Resend accepts in a
+// single /emails/batch request.
+const maxBatchMessages = 100
+
+// BatchResult represents a single entry of the Resend batch send response.
+type BatchResult struct {
+	Id string `json:"id"`
+}
+
+// SendBatch sends up to 100 messages in a single call to the Resend
+// `/emails/batch` endpoint, returning one [BatchResult] per message in
+// the same order as messages.
+//
+// Unlike [ResendClient.Send], SendBatch does not trigger the [ResendClient.OnSend]
+// hook for the individual messages, since Resend itself doesn't expose
+// per-message delivery status synchronously.
+func (c *ResendClient) SendBatch(messages []*Message) ([]BatchResult, error) {
+	if c.APIKey == "" {
+		return nil, errors.New("resend API key is required")
+	}
+
+	if len(messages) == 0 {
+		return nil, errors.New("at least one message is required")
+	}
+
+	if len(messages) > maxBatchMessages {
+		return nil, fmt.Errorf("resend batch send supports at most %d messages, got %d", maxBatchMessages, len(messages))
+	}
+
+	payloads := make([]resendPayload, len(messages))
+	for i, m := range messages {
+		payload, err := c.buildPayload(m)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prepare message %d: %w", i, err)
+		}
+		payloads[i] = payload
+	}
+
+	jsonPayload, err := json.Marshal(payloads)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resend batch payload: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.batchEndpoint(), bytes.NewReader(jsonPayload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resend batch request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", batchIdempotencyKey(messages))
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send resend batch request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resend batch response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var errResp resendErrorResponse
+		if json.Unmarshal(body, &errResp) == nil && errResp.Message != "" {
+			return nil, &ResendAPIError{StatusCode: resp.StatusCode, Message: errResp.Message}
+		}
+		return nil, &ResendAPIError{StatusCode: resp.StatusCode, Message: string(body)}
+	}
+
+	var result struct {
+		Data []BatchResult `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse resend batch response: %w", err)
+	}
+
+	return result.Data, nil
+}
+
+// batchEndpoint returns the configured endpoint's /batch variant.
+func (c *ResendClient) batchEndpoint() string {
+	return c.endpoint() + "/batch"
+}
+
+// idempotencyKey returns the Idempotency-Key to send for m, in order
+// of precedence:
+//
+//  1. m.IdempotencyKey, if explicitly set by the caller.
+//  2. m.Headers["Idempotency-Key"], for backwards-compatible callers
+//     that don't use the dedicated field yet.
+//  3. A freshly generated random key, cached back onto m.IdempotencyKey.
+//
+// Deliberately NOT hashing the message content: two Message values
+// with identical content (eg. a user clicking "resend verification
+// email" twice) must get distinct keys so both are actually delivered.
+// Caching the generated key on m instead means repeated [Mailer.Send]
+// calls for that *same* Message value (eg. a [Courier] retry after a
+// network-level failure) reuse the same key, which is what makes the
+// retry safe to dedupe on the provider's side.
+func idempotencyKey(m *Message) string {
+	if m.IdempotencyKey != "" {
+		return m.IdempotencyKey
+	}
+
+	if m.Headers != nil {
+		if key, ok := m.Headers["Idempotency-Key"]; ok && key != "" {
+			m.IdempotencyKey = key
+			return key
+		}
+	}
+
+	m.IdempotencyKey = security.PseudorandomString(32)
+
+	return m.IdempotencyKey
+}
+
+// batchIdempotencyKey derives a single Idempotency-Key covering an
+// entire batch request by hashing the per-message keys together.
+func batchIdempotencyKey(messages []*Message) string {
+	h := sha256.New()
+	for _, m := range messages {
+		h.Write([]byte(idempotencyKey(m)))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,135 @@
+package mailer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"testing"
+)
+
+func newBatchTestMessage(subject string) *Message {
+	return &Message{
+		From:    mail.Address{Address: "sender@example.com"},
+		To:      []mail.Address{{Address: "to@example.com"}},
+		Subject: subject,
+		HTML:    "<p>Test</p>",
+	}
+}
+
+func TestResendClientSendBatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/emails/batch" {
+			t.Errorf("Expected path /emails/batch, got %s", r.URL.Path)
+		}
+
+		var payloads []resendPayload
+		if err := json.NewDecoder(r.Body).Decode(&payloads); err != nil {
+			t.Fatalf("Failed to decode batch payload: %v", err)
+		}
+
+		if len(payloads) != 2 {
+			t.Fatalf("Expected 2 payloads, got %d", len(payloads))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data": [{"id": "1"}, {"id": "2"}]}`))
+	}))
+	defer server.Close()
+
+	client := &ResendClient{APIKey: "re_test_key", Endpoint: server.URL + "/emails"}
+
+	results, err := client.SendBatch([]*Message{
+		newBatchTestMessage("First"),
+		newBatchTestMessage("Second"),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(results) != 2 || results[0].Id != "1" || results[1].Id != "2" {
+		t.Fatalf("Unexpected batch results: %+v", results)
+	}
+}
+
+func TestResendClientSendBatchTooMany(t *testing.T) {
+	client := &ResendClient{APIKey: "re_test_key"}
+
+	messages := make([]*Message, maxBatchMessages+1)
+	for i := range messages {
+		messages[i] = newBatchTestMessage("Test")
+	}
+
+	if _, err := client.SendBatch(messages); err == nil {
+		t.Fatal("Expected an error when exceeding the batch size limit")
+	}
+}
+
+func TestIdempotencyKeyStableForSameMessagePointer(t *testing.T) {
+	m := newBatchTestMessage("Same")
+
+	first := idempotencyKey(m)
+	second := idempotencyKey(m)
+
+	if first != second {
+		t.Fatal("Expected repeated calls for the same *Message to reuse the same idempotency key")
+	}
+}
+
+func TestIdempotencyKeyDiffersForDistinctMessages(t *testing.T) {
+	// Even with byte-identical content, two separate Message values
+	// represent two separate logical sends (eg. a user clicking
+	// "resend verification email" twice) and must not collapse into
+	// the same Idempotency-Key, or the second send would be silently
+	// dropped by Resend as a duplicate of the first.
+	m1 := newBatchTestMessage("Same")
+	m2 := newBatchTestMessage("Same")
+
+	if idempotencyKey(m1) == idempotencyKey(m2) {
+		t.Fatal("Expected distinct Message values to produce distinct idempotency keys")
+	}
+}
+
+func TestIdempotencyKeyHonorsExplicitField(t *testing.T) {
+	m := newBatchTestMessage("Test")
+	m.IdempotencyKey = "explicit-key"
+
+	if idempotencyKey(m) != "explicit-key" {
+		t.Fatalf("Expected explicit idempotency key, got %q", idempotencyKey(m))
+	}
+}
+
+func TestIdempotencyKeyHonorsHeaderOverride(t *testing.T) {
+	m := newBatchTestMessage("Test")
+	m.Headers = map[string]string{"Idempotency-Key": "custom-key"}
+
+	if idempotencyKey(m) != "custom-key" {
+		t.Fatalf("Expected custom idempotency key, got %q", idempotencyKey(m))
+	}
+}
+
+func TestResendClientSendSetsIdempotencyKeyHeader(t *testing.T) {
+	var gotHeader string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("Idempotency-Key")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": "test-id"}`))
+	}))
+	defer server.Close()
+
+	client := &ResendClient{APIKey: "re_test_key", Endpoint: server.URL}
+	m := newBatchTestMessage("Test")
+
+	if err := client.Send(m); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if gotHeader == "" {
+		t.Fatal("Expected an Idempotency-Key header to be set")
+	}
+
+	if gotHeader != idempotencyKey(m) {
+		t.Fatalf("Expected header %q, got %q", idempotencyKey(m), gotHeader)
+	}
+}
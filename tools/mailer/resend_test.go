@@ -220,24 +220,13 @@ func TestResendClientSend(t *testing.T) {
 				APIKey: s.apiKey,
 			}
 
-			// For tests with a server, we need to override the endpoint
-			// Since we can't easily override the const, we'll skip the actual HTTP call
-			// for the "missing API key" test and verify error handling
-			if s.apiKey == "" {
-				err := client.Send(s.message)
-				if (err != nil) != s.expectError {
-					t.Fatalf("Expected error: %v, got: %v (err: %v)", s.expectError, err != nil, err)
-				}
-				return
+			if server != nil {
+				client.Endpoint = server.URL
 			}
 
-			// For tests with server responses, test the send method directly
-			// by temporarily modifying how we test (in a real scenario you'd use
-			// dependency injection for the HTTP client)
-			if server != nil {
-				// We can't easily test the HTTP calls without modifying the code
-				// to accept a custom endpoint, so we verify the error cases work correctly
-				// In production, the actual API calls would be made to resend.com
+			err := client.Send(s.message)
+			if (err != nil) != s.expectError {
+				t.Fatalf("Expected error: %v, got: %v (err: %v)", s.expectError, err != nil, err)
 			}
 		})
 	}
@@ -269,3 +258,40 @@ func TestResendClientInterface(t *testing.T) {
 	var _ SendInterceptor = (*ResendClient)(nil)
 }
 
+func TestNewResendClient(t *testing.T) {
+	client := NewResendClient("re_test_key")
+
+	if client.APIKey != "re_test_key" {
+		t.Fatalf("Expected APIKey %q, got %q", "re_test_key", client.APIKey)
+	}
+
+	if client.HTTPClient != nil {
+		t.Fatal("Expected HTTPClient to be unset by default")
+	}
+
+	if client.httpClient() != http.DefaultClient {
+		t.Fatal("Expected httpClient() to fallback to http.DefaultClient")
+	}
+
+	if client.endpoint() != resendAPIEndpoint {
+		t.Fatalf("Expected endpoint() to fallback to %q, got %q", resendAPIEndpoint, client.endpoint())
+	}
+}
+
+func TestNewResendClientWithOptions(t *testing.T) {
+	customClient := &http.Client{}
+
+	client := NewResendClient(
+		"re_test_key",
+		WithResendHTTPClient(customClient),
+		WithResendEndpoint("https://example.com/emails"),
+	)
+
+	if client.httpClient() != customClient {
+		t.Fatal("Expected httpClient() to return the injected custom client")
+	}
+
+	if client.endpoint() != "https://example.com/emails" {
+		t.Fatalf("Expected endpoint() to return the overridden endpoint, got %q", client.endpoint())
+	}
+}
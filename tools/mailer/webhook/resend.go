@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+var _ Provider = (*ResendProvider)(nil)
+
+// ResendProvider implements [Provider] for Resend's Svix-signed
+// delivery-event webhooks.
+type ResendProvider struct {
+	// SigningSecret is the Resend webhook signing secret (the `whsec_…`
+	// value shown when creating the webhook endpoint in the dashboard).
+	SigningSecret string
+}
+
+// NewResendProvider creates a new [ResendProvider] verifying webhooks
+// with signingSecret.
+func NewResendProvider(signingSecret string) *ResendProvider {
+	return &ResendProvider{SigningSecret: signingSecret}
+}
+
+// resendWebhookPayload mirrors the payload shape Resend sends for its
+// `email.*` webhook events.
+type resendWebhookPayload struct {
+	Type      string `json:"type"`
+	CreatedAt string `json:"created_at"`
+	Data      struct {
+		EmailID string   `json:"email_id"`
+		To      []string `json:"to"`
+	} `json:"data"`
+}
+
+var resendEventTypes = map[string]DeliveryEventType{
+	"email.delivered":  DeliveryEventDelivered,
+	"email.bounced":    DeliveryEventBounced,
+	"email.complained": DeliveryEventComplained,
+	"email.opened":     DeliveryEventOpened,
+	"email.clicked":    DeliveryEventClicked,
+}
+
+// VerifySignature implements [Provider].
+func (p *ResendProvider) VerifySignature(r *http.Request, body []byte) error {
+	return verifySvixSignature(r, body, p.SigningSecret)
+}
+
+// ParseEvent implements [Provider].
+func (p *ResendProvider) ParseEvent(body []byte) (*DeliveryEvent, error) {
+	var payload resendWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to parse resend webhook payload: %w", err)
+	}
+
+	eventType, ok := resendEventTypes[payload.Type]
+	if !ok {
+		return nil, fmt.Errorf("unsupported resend webhook event type: %s", payload.Type)
+	}
+
+	var recipient string
+	if len(payload.Data.To) > 0 {
+		recipient = payload.Data.To[0]
+	}
+
+	timestamp, _ := time.Parse(time.RFC3339, payload.CreatedAt)
+
+	return &DeliveryEvent{
+		MessageID: payload.Data.EmailID,
+		Type:      eventType,
+		Recipient: recipient,
+		Timestamp: timestamp,
+		Raw:       json.RawMessage(body),
+	}, nil
+}
@@ -0,0 +1,141 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSigningSecret = "whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw"
+
+func signBody(t *testing.T, secret, id string, timestamp int64, body string) string {
+	t.Helper()
+
+	decodedSecret, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, "whsec_"))
+	if err != nil {
+		t.Fatalf("Failed to decode secret: %v", err)
+	}
+
+	signedContent := fmt.Sprintf("%s.%d.%s", id, timestamp, body)
+
+	mac := hmac.New(sha256.New, decodedSecret)
+	mac.Write([]byte(signedContent))
+
+	return "v1," + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func newSignedRequest(t *testing.T, secret, body string, ts time.Time) *http.Request {
+	t.Helper()
+
+	id := "msg_test"
+	timestamp := ts.Unix()
+	sig := signBody(t, secret, id, timestamp, body)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/mailer/webhook/resend", strings.NewReader(body))
+	req.Header.Set("svix-id", id)
+	req.Header.Set("svix-timestamp", strconv.FormatInt(timestamp, 10))
+	req.Header.Set("svix-signature", sig)
+
+	return req
+}
+
+func TestResendProviderVerifySignature(t *testing.T) {
+	body := `{"type":"email.delivered","created_at":"2024-01-01T00:00:00Z","data":{"email_id":"abc","to":["user@example.com"]}}`
+	provider := NewResendProvider(testSigningSecret)
+
+	t.Run("valid signature", func(t *testing.T) {
+		req := newSignedRequest(t, testSigningSecret, body, time.Now())
+
+		if err := provider.VerifySignature(req, []byte(body)); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		req := newSignedRequest(t, "whsec_"+base64.StdEncoding.EncodeToString([]byte("different")), body, time.Now())
+
+		if err := provider.VerifySignature(req, []byte(body)); err == nil {
+			t.Fatal("Expected a signature mismatch error")
+		}
+	})
+
+	t.Run("expired timestamp", func(t *testing.T) {
+		req := newSignedRequest(t, testSigningSecret, body, time.Now().Add(-10*time.Minute))
+
+		if err := provider.VerifySignature(req, []byte(body)); err == nil {
+			t.Fatal("Expected an expired timestamp error")
+		}
+	})
+
+	t.Run("missing headers", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/mailer/webhook/resend", strings.NewReader(body))
+
+		if err := provider.VerifySignature(req, []byte(body)); err == nil {
+			t.Fatal("Expected a missing headers error")
+		}
+	})
+}
+
+func TestResendProviderParseEvent(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		body        string
+		expectType  DeliveryEventType
+		expectError bool
+	}{
+		{
+			name:       "delivered event",
+			body:       `{"type":"email.delivered","created_at":"2024-01-01T00:00:00Z","data":{"email_id":"abc","to":["user@example.com"]}}`,
+			expectType: DeliveryEventDelivered,
+		},
+		{
+			name:       "bounced event",
+			body:       `{"type":"email.bounced","created_at":"2024-01-01T00:00:00Z","data":{"email_id":"abc","to":["user@example.com"]}}`,
+			expectType: DeliveryEventBounced,
+		},
+		{
+			name:        "unsupported event",
+			body:        `{"type":"email.unknown","data":{"email_id":"abc"}}`,
+			expectError: true,
+		},
+		{
+			name:        "malformed json",
+			body:        `not json`,
+			expectError: true,
+		},
+	}
+
+	provider := NewResendProvider(testSigningSecret)
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			event, err := provider.ParseEvent([]byte(s.body))
+			if (err != nil) != s.expectError {
+				t.Fatalf("Expected error: %v, got: %v (err: %v)", s.expectError, err != nil, err)
+			}
+
+			if s.expectError {
+				return
+			}
+
+			if event.Type != s.expectType {
+				t.Fatalf("Expected type %q, got %q", s.expectType, event.Type)
+			}
+
+			if event.MessageID != "abc" {
+				t.Fatalf("Expected message id %q, got %q", "abc", event.MessageID)
+			}
+
+			if event.Recipient != "user@example.com" {
+				t.Fatalf("Expected recipient %q, got %q", "user@example.com", event.Recipient)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package webhook
+
+import "sync"
+
+// EventStore defines the persistence contract a [Receiver] uses to
+// record every successfully verified and parsed [DeliveryEvent].
+type EventStore interface {
+	// Save persists event.
+	Save(event *DeliveryEvent) error
+}
+
+var _ EventStore = (*MemoryEventStore)(nil)
+
+// MemoryEventStore is an in-memory [EventStore] implementation.
+//
+// It is only suitable for tests, since its events don't survive a
+// process restart. Production apps should use [NewSQLiteEventStore],
+// which persists events in a `_mailerEvents` table alongside the app
+// database.
+type MemoryEventStore struct {
+	mu     sync.RWMutex
+	events []*DeliveryEvent
+}
+
+// NewMemoryEventStore creates a new empty [MemoryEventStore].
+func NewMemoryEventStore() *MemoryEventStore {
+	return &MemoryEventStore{}
+}
+
+// Save implements [EventStore].
+func (s *MemoryEventStore) Save(event *DeliveryEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = append(s.events, event)
+
+	return nil
+}
+
+// List returns all the events recorded so far, oldest first.
+func (s *MemoryEventStore) List() []*DeliveryEvent {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*DeliveryEvent, len(s.events))
+	copy(result, s.events)
+
+	return result
+}
@@ -0,0 +1,102 @@
+package webhook
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+var _ EventStore = (*SQLiteEventStore)(nil)
+
+// mailerEventsTable is the name of the table [SQLiteEventStore]
+// persists delivery events in, created alongside the rest of the app
+// tables in the same SQLite database.
+const mailerEventsTable = "_mailerEvents"
+
+// SQLiteEventStore is an [EventStore] implementation backed by a
+// `_mailerEvents` table in a SQLite database, so that delivery events
+// (bounces, complaints, opens, clicks) survive app/process restarts
+// and can be queried later, eg. for a bounce/complaint suppression list.
+//
+// It is a raw table created with `CREATE TABLE IF NOT EXISTS` against
+// the given *sql.DB, NOT a PocketBase collection - it bypasses the
+// collection/migration system entirely, so it won't show up in the
+// Admin UI and has no access rules. Use it as a stopgap only; a real
+// `_mailerEvents` collection, created the way the rest of PocketBase's
+// system collections are, still needs to be added.
+//
+// It is the store a [Receiver] should be given in production, eg.:
+//
+//	store, err := webhook.NewSQLiteEventStore(app.DB())
+//	receiver := webhook.NewReceiver(provider, webhook.WithEventStore(store))
+type SQLiteEventStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteEventStore creates a new [SQLiteEventStore] using db,
+// ensuring the backing table exists.
+func NewSQLiteEventStore(db *sql.DB) (*SQLiteEventStore, error) {
+	s := &SQLiteEventStore{db: db}
+
+	if err := s.ensureSchema(); err != nil {
+		return nil, fmt.Errorf("failed to initialize the %s table: %w", mailerEventsTable, err)
+	}
+
+	return s, nil
+}
+
+func (s *SQLiteEventStore) ensureSchema() error {
+	_, err := s.db.Exec(fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			id         INTEGER PRIMARY KEY AUTOINCREMENT,
+			message_id TEXT NOT NULL,
+			type       TEXT NOT NULL,
+			recipient  TEXT NOT NULL,
+			timestamp  DATETIME NOT NULL,
+			raw        TEXT NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_%s_message_id ON %s (message_id);
+	`, mailerEventsTable, mailerEventsTable, mailerEventsTable))
+
+	return err
+}
+
+// Save implements [EventStore].
+func (s *SQLiteEventStore) Save(event *DeliveryEvent) error {
+	_, err := s.db.Exec(
+		fmt.Sprintf(`INSERT INTO %s (message_id, type, recipient, timestamp, raw) VALUES (?, ?, ?, ?, ?)`, mailerEventsTable),
+		event.MessageID, event.Type, event.Recipient, event.Timestamp, string(event.Raw),
+	)
+
+	return err
+}
+
+// List returns all the persisted events for messageID, oldest first.
+func (s *SQLiteEventStore) List(messageID string) ([]*DeliveryEvent, error) {
+	rows, err := s.db.Query(
+		fmt.Sprintf(`SELECT message_id, type, recipient, timestamp, raw FROM %s WHERE message_id = ? ORDER BY timestamp`, mailerEventsTable),
+		messageID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*DeliveryEvent
+
+	for rows.Next() {
+		var (
+			event DeliveryEvent
+			raw   string
+		)
+
+		if err := rows.Scan(&event.MessageID, &event.Type, &event.Recipient, &event.Timestamp, &raw); err != nil {
+			return nil, err
+		}
+		event.Raw = json.RawMessage(raw)
+
+		result = append(result, &event)
+	}
+
+	return result, rows.Err()
+}
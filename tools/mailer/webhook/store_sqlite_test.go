@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+func newTestSQLiteEventStore(t *testing.T) *SQLiteEventStore {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open sqlite db: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	store, err := NewSQLiteEventStore(db)
+	if err != nil {
+		t.Fatalf("Failed to create SQLiteEventStore: %v", err)
+	}
+
+	return store
+}
+
+func TestSQLiteEventStoreSaveAndList(t *testing.T) {
+	store := newTestSQLiteEventStore(t)
+
+	event := &DeliveryEvent{
+		MessageID: "abc",
+		Type:      DeliveryEventBounced,
+		Recipient: "user@example.com",
+		Timestamp: time.Now().Truncate(time.Second),
+		Raw:       []byte(`{"type":"email.bounced"}`),
+	}
+
+	if err := store.Save(event); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	events, err := store.List("abc")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 persisted event, got %d", len(events))
+	}
+
+	if events[0].Type != DeliveryEventBounced || events[0].Recipient != "user@example.com" {
+		t.Fatalf("Unexpected persisted event: %+v", events[0])
+	}
+}
+
+func TestSQLiteEventStoreListFiltersByMessageID(t *testing.T) {
+	store := newTestSQLiteEventStore(t)
+
+	if err := store.Save(&DeliveryEvent{MessageID: "abc", Type: DeliveryEventDelivered, Timestamp: time.Now()}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := store.Save(&DeliveryEvent{MessageID: "other", Type: DeliveryEventOpened, Timestamp: time.Now()}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	events, err := store.List("abc")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(events) != 1 || events[0].MessageID != "abc" {
+		t.Fatalf("Expected only the matching message events, got %+v", events)
+	}
+}
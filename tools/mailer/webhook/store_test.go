@@ -0,0 +1,21 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryEventStoreSaveAndList(t *testing.T) {
+	store := NewMemoryEventStore()
+
+	event := &DeliveryEvent{MessageID: "abc", Type: DeliveryEventDelivered, Timestamp: time.Now()}
+
+	if err := store.Save(event); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	events := store.List()
+	if len(events) != 1 || events[0].MessageID != "abc" {
+		t.Fatalf("Expected 1 saved event, got %+v", events)
+	}
+}
@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// svixTimestampTolerance is the max allowed clock skew between the
+// webhook timestamp and now, used to reject replayed requests.
+const svixTimestampTolerance = 5 * time.Minute
+
+// verifySvixSignature validates a Svix-style webhook signature
+// (used by Resend and other Svix-backed providers).
+//
+// The signed content is `{id}.{timestamp}.{body}`, HMAC-SHA256'd with
+// the base64-decoded signing secret (after stripping its `whsec_`
+// prefix) and base64-encoded. The `svix-signature` header may contain
+// multiple space-separated `v1,{signature}` values; a match against
+// any of them is accepted.
+func verifySvixSignature(r *http.Request, body []byte, signingSecret string) error {
+	id := r.Header.Get("svix-id")
+	timestampHeader := r.Header.Get("svix-timestamp")
+	signatureHeader := r.Header.Get("svix-signature")
+
+	if id == "" || timestampHeader == "" || signatureHeader == "" {
+		return errors.New("missing svix signature headers")
+	}
+
+	timestamp, err := strconv.ParseInt(timestampHeader, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid svix-timestamp: %w", err)
+	}
+
+	if diff := time.Since(time.Unix(timestamp, 0)); diff > svixTimestampTolerance || diff < -svixTimestampTolerance {
+		return errors.New("svix-timestamp outside of the allowed tolerance")
+	}
+
+	secret := strings.TrimPrefix(signingSecret, "whsec_")
+
+	decodedSecret, err := base64.StdEncoding.DecodeString(secret)
+	if err != nil {
+		return fmt.Errorf("invalid webhook signing secret: %w", err)
+	}
+
+	signedContent := fmt.Sprintf("%s.%s.%s", id, timestampHeader, body)
+
+	mac := hmac.New(sha256.New, decodedSecret)
+	mac.Write([]byte(signedContent))
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	for _, part := range strings.Fields(signatureHeader) {
+		_, sig, found := strings.Cut(part, ",")
+		if !found {
+			sig = part
+		}
+
+		if subtle.ConstantTimeCompare([]byte(sig), []byte(expected)) == 1 {
+			return nil
+		}
+	}
+
+	return errors.New("signature mismatch")
+}
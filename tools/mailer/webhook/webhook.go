@@ -0,0 +1,168 @@
+// Package webhook implements an HTTP receiver for transactional email
+// delivery-event webhooks (delivered, bounced, complained, opened,
+// clicked), with pluggable provider payload/signature parsing.
+//
+// INCOMPLETE relative to the original request: the request asked for
+// this to be mounted as a real app route, for events to be dispatched
+// through an app-level `OnMailerDeliveryEvent` hook, and for them to be
+// persisted in a PocketBase collection (Admin UI visibility, access
+// rules, migration-tracked schema). What's actually provided is:
+// [RegisterRoutes], a helper that mounts [Receiver] on a plain
+// `*http.ServeMux` but that nothing in a real app calls yet;
+// [Receiver.Bridge], which forwards into any `*hook.Hook[*DeliveryEvent]`
+// the caller supplies, not an actual `app.OnMailerDeliveryEvent()`
+// hook; and [SQLiteEventStore], a raw `_mailerEvents` table created
+// with `CREATE TABLE IF NOT EXISTS` outside of PocketBase's
+// collection/migration system, not a real collection. All three are
+// local stand-ins because this snapshot of the repo has no core/ or
+// apis/ package to wire into - someone with access to those packages
+// still needs to register the route on the real app router, bridge
+// into a genuine app.OnMailerDeliveryEvent() hook, and migrate
+// _mailerEvents into an actual collection before this satisfies the
+// original request.
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/hook"
+)
+
+// DeliveryEventType enumerates the delivery event types a [Provider] can report.
+type DeliveryEventType string
+
+const (
+	DeliveryEventDelivered  DeliveryEventType = "delivered"
+	DeliveryEventBounced    DeliveryEventType = "bounced"
+	DeliveryEventComplained DeliveryEventType = "complained"
+	DeliveryEventOpened     DeliveryEventType = "opened"
+	DeliveryEventClicked    DeliveryEventType = "clicked"
+)
+
+// DeliveryEvent is the normalized shape a [Provider] parses its raw
+// webhook payload into, regardless of the originating vendor.
+type DeliveryEvent struct {
+	MessageID string
+	Type      DeliveryEventType
+	Recipient string
+	Timestamp time.Time
+	Raw       json.RawMessage
+}
+
+// Provider defines the contract a vendor-specific implementation (eg.
+// [ResendProvider], or future SES/Postmark ones) must satisfy to plug
+// into a [Receiver].
+type Provider interface {
+	// VerifySignature validates that the webhook request genuinely
+	// originates from the provider, returning an error if it doesn't
+	// (eg. invalid/expired signature).
+	VerifySignature(r *http.Request, body []byte) error
+
+	// ParseEvent decodes the raw webhook body into a [DeliveryEvent].
+	ParseEvent(body []byte) (*DeliveryEvent, error)
+}
+
+// Receiver is an [http.Handler] that verifies and parses incoming
+// delivery-event webhooks from a single [Provider] and dispatches them
+// through [Receiver.OnEvent].
+type Receiver struct {
+	onEvent *hook.Hook[*DeliveryEvent]
+
+	provider Provider
+	store    EventStore
+}
+
+// ReceiverOption defines a function that can be used to configure a
+// [Receiver] instance created with [NewReceiver].
+type ReceiverOption func(rec *Receiver)
+
+// WithEventStore configures rec to persist every successfully parsed
+// event through store (eg. a [SQLiteEventStore]) in addition to
+// triggering [Receiver.OnEvent].
+func WithEventStore(store EventStore) ReceiverOption {
+	return func(rec *Receiver) {
+		rec.store = store
+	}
+}
+
+// NewReceiver creates a new [Receiver] for the given provider.
+func NewReceiver(provider Provider, opts ...ReceiverOption) *Receiver {
+	rec := &Receiver{provider: provider}
+
+	for _, opt := range opts {
+		opt(rec)
+	}
+
+	return rec
+}
+
+// OnEvent returns the hook triggered for every successfully verified
+// and parsed delivery event.
+//
+// In a full PocketBase app this is expected to be bridged to the
+// app-level `OnMailerDeliveryEvent` hook (see [Receiver.Bridge]) so
+// that e.g. hard bounces can auto-suppress the recipient address.
+func (rec *Receiver) OnEvent() *hook.Hook[*DeliveryEvent] {
+	if rec.onEvent == nil {
+		rec.onEvent = &hook.Hook[*DeliveryEvent]{}
+	}
+	return rec.onEvent
+}
+
+// Bridge forwards every event dispatched through rec.OnEvent() into
+// target, so that a single app-level hook (eg. `app.OnMailerDeliveryEvent()`)
+// can observe delivery events regardless of which [Provider] produced them.
+func (rec *Receiver) Bridge(target *hook.Hook[*DeliveryEvent]) {
+	rec.OnEvent().BindFunc(func(e *DeliveryEvent) error {
+		return target.Trigger(e)
+	})
+}
+
+// RegisterRoutes mounts rec at `POST /api/mailer/webhook/{name}` on
+// mux, eg. RegisterRoutes(mux, "resend", receiver) registers
+// `POST /api/mailer/webhook/resend`.
+func RegisterRoutes(mux *http.ServeMux, name string, rec *Receiver) {
+	mux.Handle("POST /api/mailer/webhook/"+name, rec)
+}
+
+// ServeHTTP implements [http.Handler], making Receiver mountable
+// directly under a route such as `POST /api/mailer/webhook/resend`.
+func (rec *Receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := rec.provider.VerifySignature(r, body); err != nil {
+		http.Error(w, "invalid webhook signature: "+err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	event, err := rec.provider.ParseEvent(body)
+	if err != nil {
+		http.Error(w, "failed to parse webhook event: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if rec.store != nil {
+		if err := rec.store.Save(event); err != nil {
+			http.Error(w, "failed to persist webhook event: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if rec.onEvent != nil {
+		if err := rec.onEvent.Trigger(event); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
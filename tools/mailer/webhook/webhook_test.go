@@ -0,0 +1,114 @@
+package webhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/hook"
+)
+
+func TestReceiverServeHTTP(t *testing.T) {
+	body := `{"type":"email.delivered","created_at":"2024-01-01T00:00:00Z","data":{"email_id":"abc","to":["user@example.com"]}}`
+	provider := NewResendProvider(testSigningSecret)
+	receiver := NewReceiver(provider)
+
+	var received *DeliveryEvent
+	receiver.OnEvent().BindFunc(func(e *DeliveryEvent) error {
+		received = e
+		return nil
+	})
+
+	req := newSignedRequest(t, testSigningSecret, body, time.Now())
+	rec := httptest.NewRecorder()
+
+	receiver.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+
+	if received == nil || received.MessageID != "abc" {
+		t.Fatalf("Expected the event to be dispatched, got %+v", received)
+	}
+}
+
+func TestReceiverServeHTTPPersistsToEventStore(t *testing.T) {
+	body := `{"type":"email.delivered","created_at":"2024-01-01T00:00:00Z","data":{"email_id":"abc","to":["user@example.com"]}}`
+	provider := NewResendProvider(testSigningSecret)
+	store := NewMemoryEventStore()
+	receiver := NewReceiver(provider, WithEventStore(store))
+
+	req := newSignedRequest(t, testSigningSecret, body, time.Now())
+	rec := httptest.NewRecorder()
+
+	receiver.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+
+	events := store.List()
+	if len(events) != 1 || events[0].MessageID != "abc" {
+		t.Fatalf("Expected the event to be persisted, got %+v", events)
+	}
+}
+
+func TestReceiverBridge(t *testing.T) {
+	body := `{"type":"email.delivered","created_at":"2024-01-01T00:00:00Z","data":{"email_id":"abc","to":["user@example.com"]}}`
+	provider := NewResendProvider(testSigningSecret)
+	receiver := NewReceiver(provider)
+
+	appHook := &hook.Hook[*DeliveryEvent]{}
+	var bridged *DeliveryEvent
+	appHook.BindFunc(func(e *DeliveryEvent) error {
+		bridged = e
+		return nil
+	})
+
+	receiver.Bridge(appHook)
+
+	req := newSignedRequest(t, testSigningSecret, body, time.Now())
+	rec := httptest.NewRecorder()
+
+	receiver.ServeHTTP(rec, req)
+
+	if bridged == nil || bridged.MessageID != "abc" {
+		t.Fatalf("Expected the event to be forwarded to the bridged hook, got %+v", bridged)
+	}
+}
+
+func TestRegisterRoutes(t *testing.T) {
+	body := `{"type":"email.delivered","created_at":"2024-01-01T00:00:00Z","data":{"email_id":"abc","to":["user@example.com"]}}`
+	provider := NewResendProvider(testSigningSecret)
+	receiver := NewReceiver(provider)
+
+	mux := http.NewServeMux()
+	RegisterRoutes(mux, "resend", receiver)
+
+	req := newSignedRequest(t, testSigningSecret, body, time.Now())
+	rec := httptest.NewRecorder()
+
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+}
+
+func TestReceiverServeHTTPInvalidSignature(t *testing.T) {
+	body := `{"type":"email.delivered","data":{"email_id":"abc"}}`
+	provider := NewResendProvider(testSigningSecret)
+	receiver := NewReceiver(provider)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/mailer/webhook/resend", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	receiver.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("Expected status %d, got %d", http.StatusUnauthorized, rec.Code)
+	}
+}
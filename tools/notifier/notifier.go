@@ -0,0 +1,56 @@
+// Package notifier implements various SMS notification channels,
+// following the same interceptable send pattern as the sibling
+// tools/mailer package.
+//
+// INCOMPLETE relative to the original request: the request asked for
+// this to be wired into core.App (an app.NewSMSClient() accessor), for
+// it to reuse [mailer.Courier] so SMS sends get the same
+// queueing/retry/persistence as email, and for a shared core.Message
+// abstraction so an OTP/verification/recovery template could render to
+// either channel. None of that is done here - only the standalone
+// TwilioClient/WebhookSMSClient clients below exist. That wiring needs
+// a core.App/apis layer to attach to, and this snapshot of the repo
+// doesn't contain one (no core/ or apis/ package). Treat this package
+// as a follow-up: someone with access to core/apis still needs to add
+// the app-level accessor, route SMS sends through Courier (or an
+// equivalent queue), and introduce the shared message abstraction
+// before this satisfies the original request.
+package notifier
+
+import (
+	"context"
+
+	"github.com/pocketbase/pocketbase/tools/hook"
+)
+
+// SMSMessage defines a single SMS notification.
+type SMSMessage struct {
+	// From is the sender phone number or alphanumeric sender id.
+	From string
+
+	// To is the recipient phone number in E.164 format.
+	To string
+
+	// Body is the plain text content of the SMS.
+	Body string
+}
+
+// SMSNotifier defines a common interface that an SMS notification
+// channel implementation must satisfy.
+type SMSNotifier interface {
+	// Send sends the specified SMS message.
+	Send(ctx context.Context, m *SMSMessage) error
+}
+
+// SMSSendEvent defines the event that is triggered on SMS send attempt.
+type SMSSendEvent struct {
+	Message *SMSMessage
+}
+
+// SendInterceptor defines an optional interface that a [SMSNotifier]
+// can implement to intercept Send calls (eg. for logging, retries,
+// metrics, etc.), mirroring [mailer.SendInterceptor].
+type SendInterceptor interface {
+	// OnSend returns the hook that is triggered on every Send call.
+	OnSend() *hook.Hook[*SMSSendEvent]
+}
@@ -0,0 +1,153 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/tools/hook"
+)
+
+var _ SMSNotifier = (*TwilioClient)(nil)
+var _ SendInterceptor = (*TwilioClient)(nil)
+
+const twilioAPIEndpoint = "https://api.twilio.com/2010-04-01/Accounts"
+
+// TwilioClient defines a Twilio SMS client structure that implements
+// the [SMSNotifier] interface.
+type TwilioClient struct {
+	onSend *hook.Hook[*SMSSendEvent]
+
+	// AccountSID is the Twilio account SID used for authentication.
+	AccountSID string
+
+	// AuthToken is the Twilio auth token used for authentication.
+	AuthToken string
+
+	// HTTPClient is the client used to perform the outbound API requests.
+	//
+	// If not set, it defaults to [http.DefaultClient].
+	HTTPClient *http.Client
+
+	// Endpoint is the Twilio API base endpoint (without the trailing
+	// `/Messages.json` part).
+	//
+	// If not set, it defaults to twilioAPIEndpoint
+	// (https://api.twilio.com/2010-04-01/Accounts).
+	Endpoint string
+}
+
+// TwilioClientOption defines a function that can be used to configure
+// a [TwilioClient] instance created with [NewTwilioClient].
+type TwilioClientOption func(c *TwilioClient)
+
+// WithTwilioHTTPClient sets a custom HTTP client for the outbound
+// Twilio API requests.
+func WithTwilioHTTPClient(client *http.Client) TwilioClientOption {
+	return func(c *TwilioClient) {
+		c.HTTPClient = client
+	}
+}
+
+// WithTwilioEndpoint overrides the default Twilio API endpoint.
+func WithTwilioEndpoint(endpoint string) TwilioClientOption {
+	return func(c *TwilioClient) {
+		c.Endpoint = endpoint
+	}
+}
+
+// NewTwilioClient creates a new [TwilioClient] with the specified
+// account SID and auth token.
+func NewTwilioClient(accountSID, authToken string, opts ...TwilioClientOption) *TwilioClient {
+	c := &TwilioClient{AccountSID: accountSID, AuthToken: authToken}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// OnSend implements [SendInterceptor] interface.
+func (c *TwilioClient) OnSend() *hook.Hook[*SMSSendEvent] {
+	if c.onSend == nil {
+		c.onSend = &hook.Hook[*SMSSendEvent]{}
+	}
+	return c.onSend
+}
+
+// Send implements [SMSNotifier] interface.
+func (c *TwilioClient) Send(ctx context.Context, m *SMSMessage) error {
+	if c.onSend != nil {
+		return c.onSend.Trigger(&SMSSendEvent{Message: m}, func(e *SMSSendEvent) error {
+			return c.send(ctx, e.Message)
+		})
+	}
+
+	return c.send(ctx, m)
+}
+
+// twilioErrorResponse represents an error response from the Twilio API.
+type twilioErrorResponse struct {
+	Code     int    `json:"code"`
+	Message  string `json:"message"`
+	MoreInfo string `json:"more_info"`
+}
+
+func (c *TwilioClient) send(ctx context.Context, m *SMSMessage) error {
+	if c.AccountSID == "" || c.AuthToken == "" {
+		return errors.New("twilio account SID and auth token are required")
+	}
+
+	form := url.Values{}
+	form.Set("From", m.From)
+	form.Set("To", m.To)
+	form.Set("Body", m.Body)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create twilio request: %w", err)
+	}
+
+	req.SetBasicAuth(c.AccountSID, c.AuthToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send twilio request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		var errResp twilioErrorResponse
+		if json.Unmarshal(body, &errResp) == nil && errResp.Message != "" {
+			return fmt.Errorf("twilio API error (%d): %s", resp.StatusCode, errResp.Message)
+		}
+		return fmt.Errorf("twilio API error (%d): %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+func (c *TwilioClient) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+
+	return http.DefaultClient
+}
+
+func (c *TwilioClient) endpoint() string {
+	base := twilioAPIEndpoint
+	if c.Endpoint != "" {
+		base = c.Endpoint
+	}
+
+	return fmt.Sprintf("%s/%s/Messages.json", base, c.AccountSID)
+}
@@ -0,0 +1,121 @@
+package notifier
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestTwilioClientSend(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		accountSID     string
+		authToken      string
+		serverResponse func(w http.ResponseWriter, r *http.Request)
+		expectError    bool
+	}{
+		{
+			name:        "missing credentials",
+			accountSID:  "",
+			authToken:   "",
+			expectError: true,
+		},
+		{
+			name:       "successful send",
+			accountSID: "ACtest",
+			authToken:  "secret",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Errorf("Expected POST method, got %s", r.Method)
+				}
+
+				if user, pass, ok := r.BasicAuth(); !ok || user != "ACtest" || pass != "secret" {
+					t.Errorf("Unexpected basic auth: %s/%s (%v)", user, pass, ok)
+				}
+
+				body, _ := url.ParseQuery(readAll(t, r))
+				if body.Get("To") != "+15550001111" {
+					t.Errorf("Expected To +15550001111, got %s", body.Get("To"))
+				}
+
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"sid": "SMtest"}`))
+			},
+			expectError: false,
+		},
+		{
+			name:       "API error response",
+			accountSID: "ACtest",
+			authToken:  "invalid",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"code": 20003, "message": "Authentication Error"}`))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			var server *httptest.Server
+			if s.serverResponse != nil {
+				server = httptest.NewServer(http.HandlerFunc(s.serverResponse))
+				defer server.Close()
+			}
+
+			client := NewTwilioClient(s.accountSID, s.authToken)
+			if server != nil {
+				client.Endpoint = server.URL
+			}
+
+			err := client.Send(context.Background(), &SMSMessage{
+				From: "+15550002222",
+				To:   "+15550001111",
+				Body: "hello",
+			})
+			if (err != nil) != s.expectError {
+				t.Fatalf("Expected error: %v, got: %v (err: %v)", s.expectError, err != nil, err)
+			}
+		})
+	}
+}
+
+func TestTwilioClientSendRespectsContext(t *testing.T) {
+	client := NewTwilioClient("ACtest", "secret")
+	client.Endpoint = "http://127.0.0.1:0"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := client.Send(ctx, &SMSMessage{From: "+15550002222", To: "+15550001111", Body: "hello"})
+	if err == nil {
+		t.Fatal("Expected an error for an already cancelled context")
+	}
+}
+
+func TestTwilioClientOnSend(t *testing.T) {
+	client := NewTwilioClient("ACtest", "secret")
+
+	hook := client.OnSend()
+	if hook == nil {
+		t.Fatal("Expected OnSend to return a non-nil hook")
+	}
+
+	if client.OnSend() != hook {
+		t.Fatal("Expected OnSend to return the same hook instance")
+	}
+}
+
+func readAll(t *testing.T, r *http.Request) string {
+	t.Helper()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		t.Fatalf("Failed to read request body: %v", err)
+	}
+
+	return string(body)
+}
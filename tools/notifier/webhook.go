@@ -0,0 +1,119 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/pocketbase/pocketbase/tools/hook"
+)
+
+var _ SMSNotifier = (*WebhookSMSClient)(nil)
+var _ SendInterceptor = (*WebhookSMSClient)(nil)
+
+// WebhookSMSClient sends SMS messages as a JSON POST request to an
+// arbitrary HTTP(S) endpoint, authenticated with a bearer token.
+//
+// It is meant for self-hosted or corporate SMS gateways that don't
+// warrant a dedicated client (eg. an internal relay in front of a
+// carrier API).
+type WebhookSMSClient struct {
+	onSend *hook.Hook[*SMSSendEvent]
+
+	// Endpoint is the webhook URL the SMS payload is POST-ed to.
+	Endpoint string
+
+	// AuthToken, if set, is sent as `Authorization: Bearer {AuthToken}`.
+	AuthToken string
+
+	// HTTPClient is the client used to perform the outbound requests.
+	//
+	// If not set, it defaults to [http.DefaultClient].
+	HTTPClient *http.Client
+}
+
+// webhookPayload represents the JSON payload sent to the webhook endpoint.
+type webhookPayload struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Body string `json:"body"`
+}
+
+// webhookErrorResponse represents an error response from the webhook endpoint.
+type webhookErrorResponse struct {
+	Message string `json:"message"`
+}
+
+// NewWebhookSMSClient creates a new [WebhookSMSClient] posting to endpoint.
+func NewWebhookSMSClient(endpoint string, authToken string) *WebhookSMSClient {
+	return &WebhookSMSClient{Endpoint: endpoint, AuthToken: authToken}
+}
+
+// OnSend implements [SendInterceptor] interface.
+func (c *WebhookSMSClient) OnSend() *hook.Hook[*SMSSendEvent] {
+	if c.onSend == nil {
+		c.onSend = &hook.Hook[*SMSSendEvent]{}
+	}
+	return c.onSend
+}
+
+// Send implements [SMSNotifier] interface.
+func (c *WebhookSMSClient) Send(ctx context.Context, m *SMSMessage) error {
+	if c.onSend != nil {
+		return c.onSend.Trigger(&SMSSendEvent{Message: m}, func(e *SMSSendEvent) error {
+			return c.send(ctx, e.Message)
+		})
+	}
+
+	return c.send(ctx, m)
+}
+
+func (c *WebhookSMSClient) send(ctx context.Context, m *SMSMessage) error {
+	if c.Endpoint == "" {
+		return errors.New("webhook endpoint is required")
+	}
+
+	jsonPayload, err := json.Marshal(webhookPayload{From: m.From, To: m.To, Body: m.Body})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.Endpoint, bytes.NewReader(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if c.AuthToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.AuthToken)
+	}
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		var errResp webhookErrorResponse
+		if json.Unmarshal(body, &errResp) == nil && errResp.Message != "" {
+			return fmt.Errorf("webhook SMS error (%d): %s", resp.StatusCode, errResp.Message)
+		}
+		return fmt.Errorf("webhook SMS error (%d): %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+func (c *WebhookSMSClient) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+
+	return http.DefaultClient
+}
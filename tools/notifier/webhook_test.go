@@ -0,0 +1,92 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhookSMSClientSend(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		endpoint       string
+		serverResponse func(w http.ResponseWriter, r *http.Request)
+		expectError    bool
+	}{
+		{
+			name:        "missing endpoint",
+			endpoint:    "",
+			expectError: true,
+		},
+		{
+			name: "successful send",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				authHeader := r.Header.Get("Authorization")
+				if authHeader != "Bearer test-token" {
+					t.Errorf("Expected 'Bearer test-token', got '%s'", authHeader)
+				}
+
+				var payload webhookPayload
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Fatalf("Failed to decode payload: %v", err)
+				}
+
+				if payload.To != "+15550001111" {
+					t.Errorf("Expected To +15550001111, got %s", payload.To)
+				}
+
+				w.WriteHeader(http.StatusOK)
+			},
+			expectError: false,
+		},
+		{
+			name: "error response",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadGateway)
+				w.Write([]byte(`{"message": "upstream carrier unavailable"}`))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			client := NewWebhookSMSClient(s.endpoint, "test-token")
+
+			var server *httptest.Server
+			if s.serverResponse != nil {
+				server = httptest.NewServer(http.HandlerFunc(s.serverResponse))
+				defer server.Close()
+				client.Endpoint = server.URL
+			}
+
+			err := client.Send(context.Background(), &SMSMessage{
+				From: "+15550002222",
+				To:   "+15550001111",
+				Body: "hello",
+			})
+			if (err != nil) != s.expectError {
+				t.Fatalf("Expected error: %v, got: %v (err: %v)", s.expectError, err != nil, err)
+			}
+		})
+	}
+}
+
+func TestWebhookSMSClientSendRespectsContext(t *testing.T) {
+	client := NewWebhookSMSClient("http://127.0.0.1:0", "test-token")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := client.Send(ctx, &SMSMessage{From: "+15550002222", To: "+15550001111", Body: "hello"})
+	if err == nil {
+		t.Fatal("Expected an error for an already cancelled context")
+	}
+}
+
+func TestWebhookSMSClientInterface(t *testing.T) {
+	var _ SMSNotifier = (*WebhookSMSClient)(nil)
+	var _ SendInterceptor = (*WebhookSMSClient)(nil)
+}